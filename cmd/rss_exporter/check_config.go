@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/mbrown007/monitoring-rss-exporter/collectors"
+)
+
+// checkConfig implements the check-config subcommand. It validates each
+// configuration file and returns the process exit code.
+func checkConfig(args []string) int {
+	app := kingpin.New("rss_exporter check-config", "Check if the config files are valid or not.")
+	files := app.Arg("config-files", "The config files to check.").Default("config.yml").Strings()
+
+	if _, err := app.Parse(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	failed := false
+	for _, f := range *files {
+		fmt.Println("Checking", f)
+
+		cfg, err := collectors.LoadConfig(f)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "  FAILED:", err)
+			failed = true
+			continue
+		}
+
+		if errs := collectors.ValidateConfig(cfg); len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Fprintln(os.Stderr, "  FAILED:", err)
+			}
+			failed = true
+			continue
+		}
+
+		fmt.Printf("  SUCCESS: %d services found\n", len(cfg.Services))
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
-	"time"
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/getsentry/sentry-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/mbrown007/monitoring-rss-exporter/collectors"
@@ -11,14 +14,25 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "check-config" {
+		os.Exit(checkConfig(os.Args[2:]))
+	}
+
 	// Instantiate the exporter with the new HTTP connector
 	e, err := collectors.NewRssExporter(connectors.NewHTTPConnector())
+	if errors.Is(err, collectors.ErrProvidersListed) {
+		return
+	}
 	if err != nil {
 		logrus.Fatal(err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	e.Start()
-	e.Serve()
 
-	sentry.Flush(5 * time.Second)
+	if err := e.Run(ctx); err != nil {
+		logrus.Fatal(err)
+	}
 }
@@ -35,7 +35,7 @@ func (s *AvayaIntegrationTestSuite) setupExporter(feedPath, url, name, provider
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
@@ -43,14 +43,16 @@ func (s *AvayaIntegrationTestSuite) setupExporter(feedPath, url, name, provider
 
 func (s *AvayaIntegrationTestSuite) TestAvayaAXPOutage() {
 	s.setupExporter("testdata/avaya_axp_outage.rss", "http://mock.avaya/axp", "avaya-test", "avaya")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status metrics
 	expected := "# HELP avaya_test_service_status Current service status\n" +
 		"# TYPE avaya_test_service_status gauge\n" +
+		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"maintenance\"} 0\n" +
 		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"ok\"} 0\n" +
 		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"outage\"} 0\n" +
-		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"service_issue\"} 1\n"
+		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"service_issue\"} 1\n" +
+		"avaya_test_service_status{customer=\"\",service=\"avaya-test\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "avaya-test_service_status")
 	s.NoError(err)
@@ -60,14 +62,16 @@ func (s *AvayaIntegrationTestSuite) TestAvayaAXPOutage() {
 
 func (s *AvayaIntegrationTestSuite) TestAvayaPreviewDialingMaintenance() {
 	s.setupExporter("testdata/avaya_preview_dialing_maintenance.rss", "http://mock.avaya/dialing", "avaya-dialing", "avaya")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
-	// Verify service status shows maintenance as service issue
+	// Verify service status shows maintenance without a known window
 	expected := "# HELP avaya_dialing_service_status Current service status\n" +
 		"# TYPE avaya_dialing_service_status gauge\n" +
+		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"maintenance\"} 1\n" +
 		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"ok\"} 0\n" +
 		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"outage\"} 0\n" +
-		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"service_issue\"} 1\n"
+		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"service_issue\"} 0\n" +
+		"avaya_dialing_service_status{customer=\"\",service=\"avaya-dialing\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "avaya-dialing_service_status")
 	s.NoError(err)
@@ -75,14 +79,16 @@ func (s *AvayaIntegrationTestSuite) TestAvayaPreviewDialingMaintenance() {
 
 func (s *AvayaIntegrationTestSuite) TestAvayaACOResolved() {
 	s.setupExporter("testdata/avaya_aco_resolved.rss", "http://mock.avaya/aco", "avaya-aco", "avaya")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows resolved (ok)
 	expected := "# HELP avaya_aco_service_status Current service status\n" +
 		"# TYPE avaya_aco_service_status gauge\n" +
+		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"maintenance\"} 0\n" +
 		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"ok\"} 1\n" +
 		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"outage\"} 0\n" +
-		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"service_issue\"} 0\n"
+		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"service_issue\"} 0\n" +
+		"avaya_aco_service_status{customer=\"\",service=\"avaya-aco\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "avaya-aco_service_status")
 	s.NoError(err)
@@ -124,18 +130,20 @@ func (s *AvayaIntegrationTestSuite) TestAvayaGlobalCommunicationsAPI() {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows incident
 	expected := "# HELP avaya_cpaas_service_status Current service status\n" +
 		"# TYPE avaya_cpaas_service_status gauge\n" +
+		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"maintenance\"} 0\n" +
 		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"ok\"} 0\n" +
 		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"outage\"} 0\n" +
-		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"service_issue\"} 1\n"
+		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"service_issue\"} 1\n" +
+		"avaya_cpaas_service_status{customer=\"\",service=\"avaya-cpaas\",state=\"unknown\"} 0\n"
 
 	err = testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "avaya-cpaas_service_status")
 	s.NoError(err)
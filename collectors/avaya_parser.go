@@ -4,9 +4,16 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("avaya", []string{"avayacloud", "avaya-cloud"}, func(maas.ServiceFeed) Scraper {
+		return enhancedAvayaParser{}
+	})
+}
+
 type enhancedAvayaParser struct{}
 
 func (enhancedAvayaParser) ServiceInfo(item *gofeed.Item) (string, string) {
@@ -26,6 +33,20 @@ func (enhancedAvayaParser) IncidentKey(item *gofeed.Item) string {
 	return strings.TrimSpace(item.Title)
 }
 
+// ClassifyStatus uses the status of the latest Statuspage update.
+func (enhancedAvayaParser) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	if state, ok := statuspageStatus(item); ok {
+		return state, 1
+	}
+	return "", 0
+}
+
+// ClassifySeverity reads the impact from the title and latest Statuspage
+// update, such as "Partial outage" or "Degraded performance".
+func (enhancedAvayaParser) ClassifySeverity(item *gofeed.Item) (Severity, bool) {
+	return statuspageSeverity(item)
+}
+
 func extractAvayaService(item *gofeed.Item) string {
 	content := strings.ToLower(item.Title + " " + item.Description + " " + item.Content)
 	
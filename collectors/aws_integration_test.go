@@ -35,7 +35,7 @@ func (s *AWSIntegrationTestSuite) setupExporter(feedPath, url, name, provider st
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
@@ -43,14 +43,16 @@ func (s *AWSIntegrationTestSuite) setupExporter(feedPath, url, name, provider st
 
 func (s *AWSIntegrationTestSuite) TestAWSEC2Outage() {
 	s.setupExporter("testdata/aws_outage.rss", "http://mock.aws/ec2", "aws-test", "aws")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status metrics
 	expected := "# HELP aws_test_service_status Current service status\n" +
 		"# TYPE aws_test_service_status gauge\n" +
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"maintenance\"} 0\n" +
 		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"ok\"} 0\n" +
 		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"outage\"} 1\n" +
-		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"service_issue\"} 0\n"
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"service_issue\"} 0\n" +
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-test_service_status")
 	s.NoError(err)
@@ -60,14 +62,16 @@ func (s *AWSIntegrationTestSuite) TestAWSEC2Outage() {
 
 func (s *AWSIntegrationTestSuite) TestAWSAthenaServiceIssue() {
 	s.setupExporter("testdata/aws_athena_us_west_2_issue.rss", "http://mock.aws/athena", "aws-athena", "aws")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows service issue
 	expected := "# HELP aws_athena_service_status Current service status\n" +
 		"# TYPE aws_athena_service_status gauge\n" +
+		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"maintenance\"} 0\n" +
 		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"ok\"} 0\n" +
 		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"outage\"} 0\n" +
-		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"service_issue\"} 1\n"
+		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"service_issue\"} 1\n" +
+		"aws_athena_service_status{customer=\"\",service=\"aws-athena\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-athena_service_status")
 	s.NoError(err)
@@ -75,15 +79,17 @@ func (s *AWSIntegrationTestSuite) TestAWSAthenaServiceIssue() {
 
 func (s *AWSIntegrationTestSuite) TestAWSMultipleItems() {
 	s.setupExporter("testdata/aws_multi_item.rss", "http://mock.aws/multi", "aws-multi", "aws")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// The multi-item feed has both resolved and active incidents
 	// The latest incident state should be reflected in the metrics
 	expected := "# HELP aws_multi_service_status Current service status\n" +
 		"# TYPE aws_multi_service_status gauge\n" +
+		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"maintenance\"} 0\n" +
 		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"ok\"} 1\n" +
 		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"outage\"} 0\n" +
-		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"service_issue\"} 0\n"
+		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"service_issue\"} 0\n" +
+		"aws_multi_service_status{customer=\"\",service=\"aws-multi\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-multi_service_status")
 	s.NoError(err)
@@ -121,18 +127,20 @@ func (s *AWSIntegrationTestSuite) TestAWSLambdaGlobal() {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows incident
 	expected := "# HELP aws_lambda_service_status Current service status\n" +
 		"# TYPE aws_lambda_service_status gauge\n" +
+		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"maintenance\"} 0\n" +
 		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"ok\"} 0\n" +
 		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"outage\"} 0\n" +
-		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"service_issue\"} 1\n"
+		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"service_issue\"} 1\n" +
+		"aws_lambda_service_status{customer=\"\",service=\"aws-lambda\",state=\"unknown\"} 0\n"
 
 	err = testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-lambda_service_status")
 	s.NoError(err)
@@ -170,18 +178,20 @@ func (s *AWSIntegrationTestSuite) TestAWSS3Resolved() {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows resolved (ok)
 	expected := "# HELP aws_s3_service_status Current service status\n" +
 		"# TYPE aws_s3_service_status gauge\n" +
+		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"maintenance\"} 0\n" +
 		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"ok\"} 1\n" +
 		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"outage\"} 0\n" +
-		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"service_issue\"} 0\n"
+		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"service_issue\"} 0\n" +
+		"aws_s3_service_status{customer=\"\",service=\"aws-s3\",state=\"unknown\"} 0\n"
 
 	err = testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-s3_service_status")
 	s.NoError(err)
@@ -4,11 +4,20 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("aws", nil, func(maas.ServiceFeed) Scraper {
+		return enhancedAWSParser{}
+	})
+}
+
 type enhancedAWSParser struct{}
 
+var awsEventTimestamp = regexp.MustCompile(`_\d+$`)
+
 func (enhancedAWSParser) ServiceInfo(item *gofeed.Item) (string, string) {
 	serviceName := extractAWSService(item)
 	region := extractAWSRegion(item)
@@ -31,7 +40,45 @@ func (enhancedAWSParser) IncidentKey(item *gofeed.Item) string {
 	for _, suffix := range suffixes {
 		key = strings.TrimSuffix(key, suffix)
 	}
-	return key
+	// Updates to an event carry the posting time, as in athena-us-west-2_1749832722.
+	return awsEventTimestamp.ReplaceAllString(key, "")
+}
+
+// ClassifyStatus uses the prefix AWS puts in front of item titles, such as
+// "[RESOLVED]" or "Service impact:", and the state suffix of the GUID.
+func (enhancedAWSParser) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	title := strings.ToUpper(strings.TrimSpace(item.Title))
+	guid := strings.ToLower(item.GUID)
+
+	switch {
+	case strings.HasPrefix(title, "[RESOLVED]") || strings.HasPrefix(title, "RESOLVED:") ||
+		strings.HasSuffix(guid, "_resolved"):
+		return "resolved", 1
+	case strings.HasPrefix(title, "OUTAGE:") || strings.HasPrefix(title, "SERVICE DISRUPTION:") ||
+		strings.HasSuffix(guid, "_outage"):
+		return "outage", 1
+	case strings.HasPrefix(title, "SERVICE IMPACT:") || strings.HasPrefix(title, "INFORMATIONAL MESSAGE:") ||
+		strings.HasPrefix(title, "PERFORMANCE ISSUES:") || strings.HasPrefix(title, "INCREASED "):
+		return "service_issue", 1
+	}
+	return "", 0
+}
+
+// ClassifySeverity maps the title prefixes of the AWS Health Dashboard, from
+// informational messages to service disruptions, to severities.
+func (enhancedAWSParser) ClassifySeverity(item *gofeed.Item) (Severity, bool) {
+	title := strings.ToUpper(strings.TrimSpace(item.Title))
+
+	switch {
+	case strings.HasPrefix(title, "OUTAGE:") || strings.HasPrefix(title, "SERVICE DISRUPTION:"):
+		return SeverityMajorOutage, true
+	case strings.HasPrefix(title, "SERVICE IMPACT:"):
+		return SeverityPartialOutage, true
+	case strings.HasPrefix(title, "INFORMATIONAL MESSAGE:") || strings.HasPrefix(title, "PERFORMANCE ISSUES:") ||
+		strings.HasPrefix(title, "INCREASED "):
+		return SeverityDegraded, true
+	}
+	return 0, false
 }
 
 // ParseAWSGUID extracts the AWS service name and region from a GUID string.
@@ -149,6 +149,11 @@ func TestEnhancedAWSParser_IncidentKey(t *testing.T) {
 			guid:        "s3-global_monitoring_update",
 			expectedKey: "s3-global",
 		},
+		{
+			name:        "GUID with event timestamp",
+			guid:        "https://status.aws.amazon.com/#athena-us-west-2_1749832722",
+			expectedKey: "athena-us-west-2",
+		},
 	}
 	
 	for _, tt := range tests {
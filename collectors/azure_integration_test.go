@@ -35,7 +35,7 @@ func (s *AzureIntegrationTestSuite) setupExporter(feedPath, url, name, provider
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
@@ -43,14 +43,16 @@ func (s *AzureIntegrationTestSuite) setupExporter(feedPath, url, name, provider
 
 func (s *AzureIntegrationTestSuite) TestAzureStorageIssue() {
 	s.setupExporter("testdata/azure_issue.rss", "http://mock.azure/storage", "azure-test", "azure")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status metrics
 	expected := "# HELP azure_test_service_status Current service status\n" +
 		"# TYPE azure_test_service_status gauge\n" +
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"maintenance\"} 0\n" +
 		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"ok\"} 0\n" +
 		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"outage\"} 0\n" +
-		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"service_issue\"} 1\n"
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"service_issue\"} 1\n" +
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"unknown\"} 0\n"
 
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "azure-test_service_status")
 	s.NoError(err)
@@ -82,18 +84,20 @@ func (s *AzureIntegrationTestSuite) TestAzureVMScaleSetsGlobal() {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows incident
 	expected := "# HELP azure_vmss_service_status Current service status\n" +
 		"# TYPE azure_vmss_service_status gauge\n" +
+		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"maintenance\"} 0\n" +
 		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"ok\"} 0\n" +
 		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"outage\"} 0\n" +
-		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"service_issue\"} 1\n"
+		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"service_issue\"} 1\n" +
+		"azure_vmss_service_status{customer=\"\",service=\"azure-vmss\",state=\"unknown\"} 0\n"
 
 	err = testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "azure-vmss_service_status")
 	s.NoError(err)
@@ -123,18 +127,20 @@ func (s *AzureIntegrationTestSuite) TestAzureSQLResolved() {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows resolved (ok)
 	expected := "# HELP azure_sql_service_status Current service status\n" +
 		"# TYPE azure_sql_service_status gauge\n" +
+		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"maintenance\"} 0\n" +
 		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"ok\"} 1\n" +
 		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"outage\"} 0\n" +
-		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"service_issue\"} 0\n"
+		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"service_issue\"} 0\n" +
+		"azure_sql_service_status{customer=\"\",service=\"azure-sql\",state=\"unknown\"} 0\n"
 
 	err = testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "azure-sql_service_status")
 	s.NoError(err)
@@ -4,9 +4,16 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("azure", nil, func(maas.ServiceFeed) Scraper {
+		return enhancedAzureParser{}
+	})
+}
+
 type enhancedAzureParser struct{}
 
 func (enhancedAzureParser) ServiceInfo(item *gofeed.Item) (string, string) {
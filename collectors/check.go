@@ -0,0 +1,63 @@
+package collectors
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+// ValidateConfig performs strict checks on a loaded configuration and returns
+// every problem found. A nil result means the configuration is valid.
+func ValidateConfig(cfg *Config) []error {
+	var errs []error
+
+	seen := make(map[string]bool, len(cfg.Services))
+	for i, svc := range cfg.Services {
+		prefix := fmt.Sprintf("services[%d]", i)
+		if svc.Name != "" {
+			prefix = fmt.Sprintf("service %q", svc.Name)
+		}
+
+		if svc.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: name must not be empty", prefix))
+		} else if seen[svc.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicate service name", prefix))
+		}
+		seen[svc.Name] = true
+
+		if name := prometheus.BuildFQName("rss_exporter", svc.Name, "service_status"); !model.IsValidLegacyMetricName(name) {
+			errs = append(errs, fmt.Errorf("%s: name produces invalid metric name %q", prefix, name))
+		}
+
+		if err := validateFeedURL(svc.URL); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+		}
+
+		if _, ok := LookupProvider(svc.Provider); svc.Provider != "" && !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown provider %q would use the generic parser", prefix, svc.Provider))
+		}
+	}
+
+	return errs
+}
+
+func validateFeedURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("url must not be empty")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("malformed url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url %q has no host", raw)
+	}
+
+	return nil
+}
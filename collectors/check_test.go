@@ -0,0 +1,97 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		errs   []string
+	}{
+		{
+			name: "Valid config",
+			config: `
+services:
+  - name: aws
+    provider: aws
+    url: https://status.aws.amazon.com/rss/all.rss
+    interval: 300
+  - name: okta
+    provider: okta
+    url: https://feeds.feedburner.com/OktaTrustRSS
+`,
+		},
+		{
+			name: "Duplicate service names",
+			config: `
+services:
+  - name: aws
+    url: https://status.aws.amazon.com/rss/all.rss
+  - name: aws
+    url: https://status.aws.amazon.com/rss/other.rss
+`,
+			errs: []string{`service "aws": duplicate service name`},
+		},
+		{
+			name: "Hyphenated name",
+			config: `
+services:
+  - name: genesys-cloud
+    url: https://status.mypurecloud.com/history.atom
+`,
+			errs: []string{`service "genesys-cloud": name produces invalid metric name "rss_exporter_genesys-cloud_service_status"`},
+		},
+		{
+			name: "Malformed URLs",
+			config: `
+services:
+  - name: missing
+  - name: relative
+    url: /history.atom
+  - name: broken
+    url: "http://[::1"
+`,
+			errs: []string{
+				`service "missing": url must not be empty`,
+				`service "relative": url "/history.atom" must use http or https`,
+				`service "broken": malformed url`,
+			},
+		},
+		{
+			name: "Unknown provider",
+			config: `
+services:
+  - name: twilio
+    provider: twilio
+    url: https://status.twilio.com/history.atom
+`,
+			errs: []string{`service "twilio": unknown provider "twilio" would use the generic parser`},
+		},
+		{
+			name: "Empty name",
+			config: `
+services:
+  - url: https://status.twilio.com/history.atom
+`,
+			errs: []string{"services[0]: name must not be empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseConfig([]byte(tt.config))
+			require.NoError(t, err)
+
+			errs := ValidateConfig(cfg)
+			require.Len(t, errs, len(tt.errs))
+			for i, err := range errs {
+				assert.Contains(t, err.Error(), tt.errs[i])
+			}
+		})
+	}
+}
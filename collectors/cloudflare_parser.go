@@ -4,9 +4,16 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("cloudflare", []string{"cloudflare-status"}, func(maas.ServiceFeed) Scraper {
+		return enhancedCloudflareParser{}
+	})
+}
+
 type enhancedCloudflareParser struct{}
 
 func (enhancedCloudflareParser) ServiceInfo(item *gofeed.Item) (string, string) {
@@ -26,6 +33,26 @@ func (enhancedCloudflareParser) IncidentKey(item *gofeed.Item) string {
 	return strings.TrimSpace(item.Title)
 }
 
+// ClassifyStatus uses the status of the latest Statuspage update.
+func (enhancedCloudflareParser) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	if state, ok := statuspageStatus(item); ok {
+		return state, 1
+	}
+	return "", 0
+}
+
+// ClassifySeverity reads the impact from the title and latest Statuspage
+// update, such as "Partial outage" or "Degraded performance".
+func (enhancedCloudflareParser) ClassifySeverity(item *gofeed.Item) (Severity, bool) {
+	return statuspageSeverity(item)
+}
+
+// Components reports the detected service as the affected component, grouped
+// by the detected region.
+func (p enhancedCloudflareParser) Components(item *gofeed.Item) []Component {
+	return serviceInfoComponents(p, item)
+}
+
 func extractCloudflareService(item *gofeed.Item) string {
 	content := strings.ToLower(item.Title + " " + item.Description + " " + item.Content)
 	
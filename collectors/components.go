@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"github.com/mmcdole/gofeed"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+// Component is a part of a service, optionally within a group such as a
+// region, as listed on the status page of a vendor.
+type Component struct {
+	Name  string
+	Group string
+}
+
+// ComponentReporter is implemented by scrapers that can tell which components
+// an item affects. Their feeds report service_component_status.
+type ComponentReporter interface {
+	Components(item *gofeed.Item) []Component
+}
+
+// serviceInfoComponents uses the service and region detected in an item as its
+// component and group.
+func serviceInfoComponents(scraper Scraper, item *gofeed.Item) []Component {
+	name, group := scraper.ServiceInfo(item)
+	if name == "" {
+		return nil
+	}
+	return []Component{{Name: name, Group: group}}
+}
+
+// feedComponentMetrics reports every component affected by an incident of the
+// feed. Components the feed does not mention are not reported.
+func (s *FeedScraper) feedComponentMetrics(incidents []*incident) []maas.Metric {
+	metrics := []maas.Metric{}
+
+	components, severities := componentSeverities(incidents)
+	for _, c := range components {
+		metrics = append(metrics, s.impactMetrics("service_component_status", severities[c], s.Config.Name, s.Config.Customer, c.Name, c.Group)...)
+	}
+
+	return metrics
+}
+
+// componentSeverities returns the components affected by incidents, in the
+// order they first appear, with the severity of the worst active incident
+// affecting each. Components only affected by resolved incidents are
+// operational.
+func componentSeverities(incidents []*incident) ([]Component, map[Component]Severity) {
+	worst := make(map[Component]Severity)
+	order := []Component{}
+
+	for _, inc := range incidents {
+		severity := SeverityOperational
+		if inc.active() {
+			severity = inc.severity
+		}
+		for _, c := range inc.components {
+			current, ok := worst[c]
+			if !ok {
+				order = append(order, c)
+			}
+			if !ok || severity > current {
+				worst[c] = severity
+			}
+		}
+	}
+
+	return order, worst
+}
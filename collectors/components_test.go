@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestFeedComponentStatus(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{
+		"http://mock.genesys/feed": `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Genesys Cloud Status</title>
+  <entry>
+    <id>tag:status.mypurecloud.com,2005:Incident/2</id>
+    <updated>2025-06-12T23:00:00Z</updated>
+    <title>Degraded Text To Speech in Americas (US East)</title>
+    <content type="html">&lt;p&gt;&lt;strong&gt;Investigating&lt;/strong&gt; - Text to speech prompts are degraded.&lt;/p&gt;</content>
+  </entry>
+  <entry>
+    <id>tag:status.mypurecloud.com,2005:Incident/1</id>
+    <updated>2025-06-12T20:00:00Z</updated>
+    <title>WhatsApp Message Errors in EMEA (Frankfurt)</title>
+    <content type="html">&lt;p&gt;&lt;strong&gt;Resolved&lt;/strong&gt; - WhatsApp messages are delivered again.&lt;/p&gt;</content>
+  </entry>
+</feed>`,
+	}}
+
+	app := kingpin.New("test", "")
+	cfg := maas.ServiceFeed{Name: "genesys", URL: "http://mock.genesys/feed", Provider: "genesyscloud", Interval: 300}
+	e, err := maas.NewExporter(app, connector,
+		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	require.NoError(t, err)
+	startExporter(t, e)
+
+	expected := `
+# HELP test_genesys_service_component_status Current status of a component of the service
+# TYPE test_genesys_service_component_status gauge
+test_genesys_service_component_status{component="text-to-speech",customer="",group="Americas (US East)",service="genesys",state="degraded_performance"} 1
+test_genesys_service_component_status{component="text-to-speech",customer="",group="Americas (US East)",service="genesys",state="major_outage"} 0
+test_genesys_service_component_status{component="text-to-speech",customer="",group="Americas (US East)",service="genesys",state="operational"} 0
+test_genesys_service_component_status{component="text-to-speech",customer="",group="Americas (US East)",service="genesys",state="partial_outage"} 0
+test_genesys_service_component_status{component="text-to-speech",customer="",group="Americas (US East)",service="genesys",state="under_maintenance"} 0
+test_genesys_service_component_status{component="whatsapp-integration",customer="",group="EMEA (Frankfurt)",service="genesys",state="degraded_performance"} 0
+test_genesys_service_component_status{component="whatsapp-integration",customer="",group="EMEA (Frankfurt)",service="genesys",state="major_outage"} 0
+test_genesys_service_component_status{component="whatsapp-integration",customer="",group="EMEA (Frankfurt)",service="genesys",state="operational"} 1
+test_genesys_service_component_status{component="whatsapp-integration",customer="",group="EMEA (Frankfurt)",service="genesys",state="partial_outage"} 0
+test_genesys_service_component_status{component="whatsapp-integration",customer="",group="EMEA (Frankfurt)",service="genesys",state="under_maintenance"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "test_genesys_service_component_status"))
+}
+
+func TestComponentSeverities(t *testing.T) {
+	api := Component{Name: "API", Group: "EU"}
+	web := Component{Name: "Dashboard", Group: "EU"}
+
+	components, severities := componentSeverities([]*incident{
+		{state: "service_issue", severity: SeverityDegraded, components: []Component{api}},
+		{state: "outage", severity: SeverityMajorOutage, components: []Component{api, web}},
+		{state: "resolved", severity: SeverityOperational, components: []Component{api}},
+	})
+	assert.Equal(t, []Component{api, web}, components)
+	assert.Equal(t, SeverityMajorOutage, severities[api])
+	assert.Equal(t, SeverityMajorOutage, severities[web])
+
+	_, severities = componentSeverities([]*incident{
+		{state: "resolved", severity: SeverityOperational, components: []Component{api}},
+	})
+	assert.Equal(t, SeverityOperational, severities[api])
+}
+
+func TestComponentsOnlyForReporters(t *testing.T) {
+	_, ok := Scraper(enhancedAWSParser{}).(ComponentReporter)
+	assert.False(t, ok)
+	_, ok = Scraper(enhancedCloudflareParser{}).(ComponentReporter)
+	assert.True(t, ok)
+}
@@ -0,0 +1,238 @@
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultInterval = 300
+
+// Config defines exporter settings loaded from YAML configuration.
+type Config struct {
+	ListenAddress  string                 `yaml:"listen_address"`
+	ListenPort     int                    `yaml:"listen_port"`
+	LogLevel       string                 `yaml:"log_level"`
+	MaxConcurrency int                    `yaml:"max_concurrency"`
+	HTTPClient     *maas.HTTPClientConfig `yaml:"http_client"`
+	Retry          *maas.RetryPolicy      `yaml:"retry"`
+	Services       []maas.ServiceFeed     `yaml:"services"`
+
+	// set holds the top-level keys present in the file, so that settings
+	// left out are told apart from ones set to their zero value.
+	set map[string]bool
+}
+
+// LoadConfig reads the YAML configuration at path. Unknown keys and invalid
+// top-level settings are reported as errors.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ParseConfig decodes and validates a YAML configuration document. Services
+// without an interval are polled every five minutes, and their http_client
+// and retry blocks are completed from the top-level ones.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var keys map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	cfg.set = make(map[string]bool, len(keys))
+	for k := range keys {
+		cfg.set[k] = true
+	}
+
+	if cfg.set["listen_port"] && (cfg.ListenPort < 1 || cfg.ListenPort > 65535) {
+		return nil, fmt.Errorf("listen_port %d is out of range", cfg.ListenPort)
+	}
+	if cfg.set["log_level"] {
+		if _, err := logrus.ParseLevel(cfg.LogLevel); err != nil {
+			return nil, fmt.Errorf("log_level: %w", err)
+		}
+	}
+	if cfg.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("max_concurrency %d must not be negative", cfg.MaxConcurrency)
+	}
+
+	if cfg.HTTPClient != nil {
+		if _, err := connectors.NewHTTPClient(cfg.HTTPClient); err != nil {
+			return nil, fmt.Errorf("http_client: %w", err)
+		}
+	}
+
+	if err := connectors.ValidateRetryPolicy(cfg.Retry); err != nil {
+		return nil, fmt.Errorf("retry: %w", err)
+	}
+
+	for i, svc := range cfg.Services {
+		if svc.Interval < 0 {
+			return nil, fmt.Errorf("service %q: interval %d must not be negative", svc.Name, svc.Interval)
+		}
+		if svc.Jitter < 0 {
+			return nil, fmt.Errorf("service %q: jitter %d must not be negative", svc.Name, svc.Jitter)
+		}
+		if svc.Interval == 0 {
+			svc.Interval = defaultInterval
+		}
+
+		svc.HTTPClient = inheritHTTPClient(cfg.HTTPClient, svc.HTTPClient)
+		svc.Retry = inheritRetryPolicy(cfg.Retry, svc.Retry)
+		cfg.Services[i] = svc
+
+		if err := connectors.ValidateRetryPolicy(svc.Retry); err != nil {
+			return nil, fmt.Errorf("service %q: retry: %w", svc.Name, err)
+		}
+
+		if svc.HTTPClient != nil && svc.HTTPClient != cfg.HTTPClient {
+			if _, err := connectors.NewHTTPClient(svc.HTTPClient); err != nil {
+				return nil, fmt.Errorf("service %q: http_client: %w", svc.Name, err)
+			}
+		}
+		if svc.Type != "" && !contains(serviceTypes, svc.Type) {
+			return nil, fmt.Errorf("service %q: unknown type %q", svc.Name, svc.Type)
+		}
+		if _, err := newServiceFilter(svc); err != nil {
+			return nil, fmt.Errorf("service %q: %w", svc.Name, err)
+		}
+		if err := validateStalenessPolicy(svc.Staleness); err != nil {
+			return nil, fmt.Errorf("service %q: staleness: %w", svc.Name, err)
+		}
+		if strings.EqualFold(svc.Provider, "rules") {
+			if _, err := newRulesParser(svc.Rules); err != nil {
+				return nil, fmt.Errorf("service %q: %w", svc.Name, err)
+			}
+		}
+	}
+
+	return &cfg, nil
+}
+
+// inheritHTTPClient returns the settings of svc, with the ones it leaves unset
+// taken from global. Headers are merged, the service winning. Credentials and
+// client certificates are inherited as a whole, so a service setting its own
+// never mixes them with the global ones.
+func inheritHTTPClient(global, svc *maas.HTTPClientConfig) *maas.HTTPClientConfig {
+	if global == nil {
+		return svc
+	}
+	if svc == nil {
+		return global
+	}
+
+	merged := *svc
+	if merged.ProxyURL == "" {
+		merged.ProxyURL = global.ProxyURL
+	}
+	if merged.NoProxy == "" {
+		merged.NoProxy = global.NoProxy
+	}
+	if merged.CAFile == "" {
+		merged.CAFile = global.CAFile
+	}
+	if merged.CertFile == "" && merged.KeyFile == "" {
+		merged.CertFile, merged.KeyFile = global.CertFile, global.KeyFile
+	}
+	merged.InsecureSkipVerify = merged.InsecureSkipVerify || global.InsecureSkipVerify
+	if merged.UserAgent == "" {
+		merged.UserAgent = global.UserAgent
+	}
+	if merged.BasicAuth == nil && merged.BearerTokenFile == "" {
+		merged.BasicAuth, merged.BearerTokenFile = global.BasicAuth, global.BearerTokenFile
+	}
+	if merged.Timeout == 0 {
+		merged.Timeout = global.Timeout
+	}
+
+	if len(global.Headers) > 0 {
+		merged.Headers = make(map[string]string, len(global.Headers)+len(svc.Headers))
+		for k, v := range global.Headers {
+			merged.Headers[k] = v
+		}
+		for k, v := range svc.Headers {
+			merged.Headers[k] = v
+		}
+	}
+
+	return &merged
+}
+
+// inheritRetryPolicy returns the settings of svc, with the ones it leaves
+// unset taken from global.
+func inheritRetryPolicy(global, svc *maas.RetryPolicy) *maas.RetryPolicy {
+	if global == nil {
+		return svc
+	}
+	if svc == nil {
+		return global
+	}
+
+	merged := *svc
+	if merged.MaxAttempts == 0 {
+		merged.MaxAttempts = global.MaxAttempts
+	}
+	if merged.BaseBackoff == 0 {
+		merged.BaseBackoff = global.BaseBackoff
+	}
+	if merged.MaxBackoff == 0 {
+		merged.MaxBackoff = global.MaxBackoff
+	}
+	if merged.Jitter == 0 {
+		merged.Jitter = global.Jitter
+	}
+	if merged.RetryOn == nil {
+		merged.RetryOn = global.RetryOn
+	}
+	if merged.RespectRetryAfter == nil {
+		merged.RespectRetryAfter = global.RespectRetryAfter
+	}
+
+	return &merged
+}
+
+// options returns the exporter options for the top-level settings present in
+// the configuration file.
+func (c *Config) options() []func(*maas.Exporter) {
+	var options []func(*maas.Exporter)
+
+	if c.set["listen_address"] {
+		options = append(options, maas.WithListenAddress(c.ListenAddress))
+	}
+	if c.set["listen_port"] {
+		options = append(options, maas.WithListenPort(c.ListenPort))
+	}
+	if c.set["log_level"] {
+		options = append(options, maas.WithLogLevel(c.LogLevel))
+	}
+	if c.set["max_concurrency"] {
+		options = append(options, maas.WithMaxConcurrency(c.MaxConcurrency))
+	}
+
+	return options
+}
@@ -0,0 +1,213 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigTopLevelSettings(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+listen_address: 0.0.0.0
+listen_port: 9091
+log_level: debug
+max_concurrency: 4
+services:
+  - name: aws
+    provider: aws
+    url: https://status.aws.amazon.com/rss/all.rss
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.0.0.0", cfg.ListenAddress)
+	assert.Equal(t, 9091, cfg.ListenPort)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 4, cfg.MaxConcurrency)
+	require.Len(t, cfg.Services, 1)
+	assert.Equal(t, defaultInterval, cfg.Services[0].Interval)
+	assert.Len(t, cfg.options(), 4)
+}
+
+func TestConfigOptionsOnlyForKeysInFile(t *testing.T) {
+	cfg, err := ParseConfig([]byte("max_concurrency: 0\n"))
+	require.NoError(t, err)
+	assert.Len(t, cfg.options(), 1)
+
+	cfg, err = ParseConfig([]byte("services: []\n"))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.options())
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "Unknown top-level key",
+			config: "listen_prot: 9091\n",
+			err:    "field listen_prot not found",
+		},
+		{
+			name:   "Unknown service key",
+			config: "services:\n  - name: aws\n    urll: https://example.com\n",
+			err:    "field urll not found",
+		},
+		{
+			name:   "Port out of range",
+			config: "listen_port: 70000\n",
+			err:    "listen_port 70000 is out of range",
+		},
+		{
+			name:   "Port zero",
+			config: "listen_port: 0\n",
+			err:    "listen_port 0 is out of range",
+		},
+		{
+			name:   "Invalid log level",
+			config: "log_level: loud\n",
+			err:    "log_level: not a valid logrus Level",
+		},
+		{
+			name:   "Negative max_concurrency",
+			config: "max_concurrency: -1\n",
+			err:    "max_concurrency -1 must not be negative",
+		},
+		{
+			name:   "Negative interval",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    interval: -5\n",
+			err:    `service "aws": interval -5 must not be negative`,
+		},
+		{
+			name:   "Negative jitter",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    jitter: -1\n",
+			err:    `service "aws": jitter -1 must not be negative`,
+		},
+		{
+			name:   "Unknown service type",
+			config: "services:\n  - name: aws\n    type: json\n    url: https://example.com\n",
+			err:    `service "aws": unknown type "json"`,
+		},
+		{
+			name:   "Invalid filter",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    include:\n      title: '('\n",
+			err:    `service "aws": include: title:`,
+		},
+		{
+			name:   "Unknown staleness policy",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    staleness:\n      policy: forget\n",
+			err:    `service "aws": staleness: unknown policy "forget"`,
+		},
+		{
+			name:   "Invalid retry policy",
+			config: "retry:\n  base_backoff: 1m\n  max_backoff: 10s\n",
+			err:    "retry: base_backoff 1m0s exceeds max_backoff 10s",
+		},
+		{
+			name:   "Invalid service retry policy",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    retry:\n      retry_on: [42]\n",
+			err:    `service "aws": retry: retry_on: invalid status code 42`,
+		},
+		{
+			name:   "Invalid global http_client",
+			config: "http_client:\n  proxy_url: proxy:3128\n",
+			err:    "http_client: proxy_url: invalid url",
+		},
+		{
+			name:   "Invalid service http_client",
+			config: "services:\n  - name: aws\n    url: https://example.com\n    http_client:\n      ca_file: /nonexistent/ca.pem\n",
+			err:    `service "aws": http_client: ca_file:`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.config))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestParseConfigHTTPClientInheritance(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+http_client:
+  proxy_url: http://proxy.internal:3128
+  no_proxy: status.internal
+  user_agent: acme-monitoring
+  headers:
+    X-Team: sre
+  basic_auth:
+    username: monitor
+    password_file: /etc/rss_exporter/password
+services:
+  - name: aws
+    url: https://status.aws.amazon.com/rss/all.rss
+  - name: partner
+    url: https://status.partner.example/feed
+    http_client:
+      timeout: 30s
+      headers:
+        X-Api-Key: key
+      bearer_token_file: /etc/rss_exporter/partner-token
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 2)
+
+	assert.Same(t, cfg.HTTPClient, cfg.Services[0].HTTPClient)
+
+	partner := cfg.Services[1].HTTPClient
+	assert.Equal(t, "http://proxy.internal:3128", partner.ProxyURL)
+	assert.Equal(t, "status.internal", partner.NoProxy)
+	assert.Equal(t, "acme-monitoring", partner.UserAgent)
+	assert.Equal(t, 30*time.Second, partner.Timeout)
+	assert.Equal(t, map[string]string{"X-Team": "sre", "X-Api-Key": "key"}, partner.Headers)
+	assert.Nil(t, partner.BasicAuth)
+	assert.Equal(t, "/etc/rss_exporter/partner-token", partner.BearerTokenFile)
+}
+
+func TestParseConfigRetryInheritance(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+retry:
+  max_attempts: 5
+  base_backoff: 500ms
+  max_backoff: 20s
+  retry_on: [429, 503]
+services:
+  - name: aws
+    url: https://status.aws.amazon.com/rss/all.rss
+  - name: partner
+    url: https://status.partner.example/feed
+    retry:
+      max_attempts: 2
+      respect_retry_after: false
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 2)
+
+	assert.Same(t, cfg.Retry, cfg.Services[0].Retry)
+
+	partner := cfg.Services[1].Retry
+	assert.Equal(t, 2, partner.MaxAttempts)
+	assert.Equal(t, 500*time.Millisecond, partner.BaseBackoff)
+	assert.Equal(t, 20*time.Second, partner.MaxBackoff)
+	assert.Equal(t, []int{429, 503}, partner.RetryOn)
+	require.NotNil(t, partner.RespectRetryAfter)
+	assert.False(t, *partner.RespectRetryAfter)
+}
+
+func TestLoadConfigExample(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join("..", "config.example.yml"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, cfg.Services)
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,55 +1,112 @@
 package collectors
 
 import (
+	"errors"
 	"os"
 	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
 	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
-	"gopkg.in/yaml.v3"
 )
 
-// Config defines exporter settings loaded from YAML configuration.
-type Config struct {
-	Services []maas.ServiceFeed `yaml:"services"`
-}
-
+// ErrProvidersListed is returned by NewRssExporter once it has printed the
+// known providers for --providers.list. The caller should exit successfully.
+var ErrProvidersListed = errors.New("providers listed")
 
 // NewRssExporter constructs a maas exporter with feed scrapers based on config.
+// Top-level settings from the config file become the defaults for the
+// corresponding command line flags. A flag or environment variable setting a
+// different value than the file is reported as an error.
 func NewRssExporter(c maas.Connector, options ...func(*maas.Exporter)) (*maas.Exporter, error) {
 	app := kingpin.New("rss_exporter", "Exporter for RSS/Atom status feeds.").DefaultEnvars()
-	app.Flag("config.file", "RSS exporter configuration file.").Default("config.yml").String()
-
-	// Read and parse the config file using default or specified path
-	configPath := "config.yml"
-	// Look for --config.file in args to override default
-	for i, arg := range os.Args[1:] {
-		if strings.HasPrefix(arg, "--config.file=") {
-			configPath = strings.TrimPrefix(arg, "--config.file=")
-		} else if arg == "--config.file" && i+1 < len(os.Args[1:]) {
-			configPath = os.Args[i+2] // next argument
+	configPath := app.Flag("config.file", "RSS exporter configuration file.").Default("config.yml").String()
+	list := app.Flag("providers.list", "List the known providers and exit.").Bool()
+	store := &lazyStore{
+		path: app.Flag("storage.path", "Directory where incident state is kept across restarts. State is kept in memory when empty.").Default("").String(),
+	}
+
+	// The scrapers, and so their flags, depend on the config file. The flags
+	// known so far are parsed first to find it, and to list the providers
+	// without needing one.
+	if _, err := app.Parse(earlyArgs(app, maas.Args(options...))); err != nil {
+		return nil, err
+	}
+	if *list {
+		if err := listProviders(os.Stdout); err != nil {
+			return nil, err
 		}
+		return nil, ErrProvidersListed
 	}
 
-	yamlFile, err := os.ReadFile(configPath)
+	cfg, err := LoadConfig(*configPath)
 	if err != nil {
 		return nil, err
 	}
-	var cfg Config
-	if err := yaml.Unmarshal(yamlFile, &cfg); err != nil {
+
+	reloader, err := newConfigReloader(app, *configPath, cfg)
+	if err != nil {
 		return nil, err
 	}
+	reloader.store = store
+	reloader.connector = c
 
 	// Create scrapers based on config
 	scrapers := []*maas.ScheduledScraper{}
 	for _, svc := range cfg.Services {
-		if svc.Interval <= 0 {
-			svc.Interval = 300
-		}
-		scrapers = append(scrapers, NewFeedCollector(app, svc))
+		scrapers = append(scrapers, newServiceCollector(app, svc, store))
 	}
 
 	// Create the exporter with scrapers
-	options = append(options, maas.WithScheduledScrapers(scrapers...))
-	return maas.NewExporter(app, c, options...)
+	options = append(append(cfg.options(), maas.WithScheduledScrapers(scrapers...), maas.WithReloader(reloader)), options...)
+	e, err := maas.NewExporter(app, c, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open the store now that --storage.path is known, so a bad path fails
+	// at startup rather than on the first scrape.
+	if _, err := store.open(); err != nil {
+		return nil, err
+	}
+
+	reloader.exporter = e
+
+	return e, nil
+}
+
+// earlyArgs returns the arguments setting flags already declared on app,
+// leaving out the ones for flags declared later and their values. Help and
+// the hidden flags of kingpin are left for the full parse.
+func earlyArgs(app *kingpin.Application, args []string) []string {
+	early := []string{}
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			break
+		}
+		if !strings.HasPrefix(args[i], "--") {
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(args[i], "--"), "=")
+		flag := app.GetFlag(name)
+		if negated := app.GetFlag(strings.TrimPrefix(name, "no-")); flag == nil && negated != nil && negated.Model().IsBoolFlag() {
+			flag = negated
+		}
+		if flag == nil || flag == app.HelpFlag || flag.Model().Hidden {
+			continue
+		}
+
+		early = append(early, args[i])
+		if !hasValue && !flag.Model().IsBoolFlag() && i+1 < len(args) {
+			i++
+			early = append(early, args[i])
+		}
+	}
+	return early
+}
+
+// newServiceCollector builds the scheduled scraper of a service, both at
+// startup and on reload.
+func newServiceCollector(app *kingpin.Application, svc maas.ServiceFeed, store IncidentStore) *maas.ScheduledScraper {
+	return NewFeedCollector(app, svc, WithIncidentStore(store))
 }
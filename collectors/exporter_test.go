@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestNewRssExporterConfigFileFromArgs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("services:\n  - name: aws\n    url: http://mock.aws/feed\n"), 0o600))
+
+	e, err := NewRssExporter(&connectors.MockHTTPConnector{},
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--aws.timeout", "10s", "--config.file", path, "--web.listen-port=0"}),
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, e)
+}
+
+func TestNewRssExporterListsProviders(t *testing.T) {
+	_, err := NewRssExporter(&connectors.MockHTTPConnector{},
+		maas.WithArgs([]string{"--providers.list", "--config.file=missing.yml"}),
+	)
+	assert.ErrorIs(t, err, ErrProvidersListed)
+}
+
+func TestNewRssExporterListsProvidersFromEnv(t *testing.T) {
+	t.Setenv("RSS_EXPORTER_PROVIDERS_LIST", "true")
+
+	_, err := NewRssExporter(&connectors.MockHTTPConnector{},
+		maas.WithArgs([]string{"--config.file=missing.yml"}),
+	)
+	assert.ErrorIs(t, err, ErrProvidersListed)
+}
+
+func TestEarlyArgs(t *testing.T) {
+	app := kingpin.New("test", "")
+	app.Flag("config.file", "").String()
+	app.Flag("providers.list", "").Bool()
+
+	args := []string{"--aws.timeout", "10s", "--config.file", "a.yml", "--no-providers.list", "--help", "--", "--config.file=b.yml"}
+	assert.Equal(t, []string{"--config.file", "a.yml", "--no-providers.list"}, earlyArgs(app, args))
+}
@@ -1,97 +1,367 @@
 package collectors
 
 import (
-	"strings"
+	"context"
+	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/mmcdole/gofeed"
 	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
 	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 
 	"github.com/mbrown007/monitoring-rss-exporter/connectors"
 )
 
-// NewFeedCollector creates a scheduled scraper for a single RSS feed.
-func NewFeedCollector(app *kingpin.Application, serviceConfig maas.ServiceFeed) *maas.ScheduledScraper {
+// defaultScrapeTimeout bounds a scrape, retries included, unless the interval
+// is shorter. The --<service>.timeout flag overrides it.
+const defaultScrapeTimeout = 30 * time.Second
+
+// NewFeedCollector creates a scheduled scraper for a single RSS feed. A service
+// without an interval is polled every five minutes, as in ParseConfig, and the
+// jitter defaults to a tenth of the interval.
+func NewFeedCollector(app *kingpin.Application, serviceConfig maas.ServiceFeed, options ...func(*FeedScraper)) *maas.ScheduledScraper {
+	interval := serviceConfig.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	timeout := defaultScrapeTimeout
+	if frequency := time.Duration(interval) * time.Second; frequency < timeout {
+		timeout = frequency
+	}
+	jitter := time.Duration(serviceConfig.Jitter) * time.Second
+	if jitter <= 0 {
+		jitter = time.Duration(interval) * time.Second / 10
+	}
+
 	return maas.NewScheduledScraper(
 		serviceConfig.Name,
-		NewFeedScraper(serviceConfig),
+		NewFeedScraper(serviceConfig, options...),
 		maas.WithSchedule(maas.NewSchedule(
-			maas.WithFrequency(time.Duration(serviceConfig.Interval)*time.Second),
+			maas.WithFrequency(time.Duration(interval)*time.Second),
+			maas.WithTimeout(timeout),
+			maas.WithJitter(jitter),
 		)),
 		maas.WithDescription(app, "service_status", "Current service status", []string{"service", "customer", "state"}),
+		maas.WithDescription(app, "service_status_data_age_seconds", "Time since the last successful scrape of the service, as of the latest scrape", []string{"service", "customer"}),
+		maas.WithDescription(app, "service_severity", "Severity of the most severe active incident, from 0 (operational) to 4 (major outage)", []string{"service", "customer"}),
+		maas.WithDescription(app, "service_impact", "Current service impact", []string{"service", "customer", "impact"}),
+		maas.WithDescription(app, "service_component_status", "Current status of a component of the service", []string{"service", "customer", "component", "group", "state"}),
 		maas.WithDescription(app, "service_issue_info", "Details for active service issues", []string{"service", "customer", "service_name", "region", "title", "link", "guid"}),
+		maas.WithDescription(app, "service_ignored_issue_info", "Details for active service issues left out by the include and exclude filters", []string{"service", "customer", "service_name", "region", "title", "link", "guid"}),
+		maas.WithDescription(app, "service_incident_start_timestamp_seconds", "Time of the first update of an incident", []string{"service", "customer", "incident", "state"}),
+		maas.WithDescription(app, "service_incident_last_update_timestamp_seconds", "Time of the latest update of an incident", []string{"service", "customer", "incident", "state"}),
+		maas.WithDescription(app, "service_maintenance_window_start_timestamp_seconds", "Scheduled start of announced maintenance", []string{"service", "customer", "incident", "title"}),
+		maas.WithDescription(app, "service_maintenance_window_end_timestamp_seconds", "Scheduled end of announced maintenance", []string{"service", "customer", "incident", "title"}),
+		maas.WithDescription(app, "service_incidents_total", "Incidents observed, by state when first seen", []string{"service", "customer", "state"}),
+		maas.WithDescription(app, "feed_items", "Items in the feed", []string{"service", "customer"}),
+		maas.WithDescription(app, "feed_updated_timestamp_seconds", "Time the feed declares it was last updated", []string{"service", "customer"}),
+		maas.WithDescription(app, "feed_newest_item_timestamp_seconds", "Publication time of the newest item in the feed", []string{"service", "customer"}),
+		maas.WithDescription(app, "feed_newest_item_age_seconds", "Age of the newest item in the feed, as of the latest scrape", []string{"service", "customer"}),
 	)
 }
 
-// FeedScraper holds configuration for scraping a feed.
+// FeedScraper holds configuration for scraping a feed. The incidents it has
+// seen are kept in an IncidentStore. While the feed cannot be read, the
+// staleness policy of the service decides what is reported.
 type FeedScraper struct {
 	Config maas.ServiceFeed
 	Parser Scraper
+	Store  IncidentStore
+
+	filter *serviceFilter
+	stale  *staleness
+	mu     sync.Mutex
+	state  *ServiceIncidents
 }
 
-// NewFeedScraper returns a new FeedScraper instance.
-func NewFeedScraper(cfg maas.ServiceFeed) *FeedScraper {
-	return &FeedScraper{
+// NewFeedScraper returns a new FeedScraper instance. Incidents are kept in
+// memory unless another store is configured.
+func NewFeedScraper(cfg maas.ServiceFeed, options ...func(*FeedScraper)) *FeedScraper {
+	s := &FeedScraper{
 		Config: cfg,
-		Parser: ScraperForService(cfg.Provider, cfg.Name),
+		Parser: scraperFor(cfg),
+		Store:  NewMemoryStore(),
+		stale:  newStaleness(cfg.Staleness),
 	}
+
+	filter, err := newServiceFilter(cfg)
+	if err != nil {
+		log.Errorf("%s: invalid filter, reporting every incident: %s", cfg.Name, err)
+	}
+	s.filter = filter
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
 }
 
-// Scrape fetches the feed and converts status into metrics.
+// WithIncidentStore sets the store used to remember incidents.
+func WithIncidentStore(store IncidentStore) func(*FeedScraper) {
+	return func(s *FeedScraper) {
+		s.Store = store
+	}
+}
+
+// Scrape fetches the feed, or the Statuspage summary for services of type
+// statuspage, and converts status into metrics.
 func (s *FeedScraper) Scrape(c maas.Connector) ([]maas.Metric, error) {
-	metrics := []maas.Metric{}
+	return s.ScrapeContext(context.Background(), c)
+}
+
+// ScrapeContext is Scrape bounded by ctx. Requests in flight are aborted when
+// ctx is done.
+//
+// A failed scrape returns its error along with the metrics to report in the
+// meantime: the last successful ones, none, or the unknown state, following
+// the staleness policy of the service.
+func (s *FeedScraper) ScrapeContext(ctx context.Context, c maas.Connector) ([]maas.Metric, error) {
+	now := time.Now()
+
+	metrics, err := s.scrape(ctx, c)
+	if err != nil {
+		return s.staleMetrics(now), err
+	}
+
+	s.stale.succeeded(metrics, now)
+	return append(metrics, s.dataAgeMetric(0)), nil
+}
 
-	feed, err := c.Execute(connectors.HTTPQuery{URL: s.Config.URL})
+// staleMetrics records a failed scrape and returns the metrics to report
+// until the feed can be read again. nil keeps the previous metrics.
+func (s *FeedScraper) staleMetrics(now time.Time) []maas.Metric {
+	last, lastSuccess, expired := s.stale.failed(now)
+
+	var metrics []maas.Metric
+	switch {
+	case !expired || s.stale.policy == stalenessKeep:
+		if last == nil {
+			return nil
+		}
+		metrics = append(metrics, last...)
+	case s.stale.policy == stalenessDrop:
+		metrics = []maas.Metric{}
+	case s.stale.policy == stalenessUnknown:
+		metrics = s.statusMetrics(stateUnknown)
+	}
+
+	if !lastSuccess.IsZero() {
+		metrics = append(metrics, s.dataAgeMetric(now.Sub(lastSuccess)))
+	}
+	return metrics
+}
+
+func (s *FeedScraper) dataAgeMetric(age time.Duration) maas.Metric {
+	return maas.NewMetric("service_status_data_age_seconds", prometheus.GaugeValue, age.Seconds(), []string{s.Config.Name, s.Config.Customer})
+}
+
+// scrape reads the feed and converts it into metrics.
+func (s *FeedScraper) scrape(ctx context.Context, c maas.Connector) ([]maas.Metric, error) {
+	cc := maas.AdaptConnector(c)
+
+	if s.Config.Type == serviceTypeStatuspage {
+		res, err := cc.ExecuteContext(ctx, connectors.StatuspageQuery{URL: s.Config.URL, HTTPClient: s.Config.HTTPClient, Retry: s.Config.Retry})
+		if err != nil {
+			return nil, err
+		}
+
+		summary := res.(*connectors.StatuspageSummary)
+		incidents, ignored := s.filter.split(statuspageIncidents(summary))
+		metrics := s.componentMetrics(summary.Components)
+		metrics = append(metrics, s.ignoredMetrics(ignored)...)
+		return append(metrics, s.incidentMetrics(incidents)...), nil
+	}
+
+	feed, err := cc.ExecuteContext(ctx, connectors.HTTPQuery{URL: s.Config.URL, HTTPClient: s.Config.HTTPClient, Retry: s.Config.Retry})
 	if err != nil {
 		return nil, err
 	}
 
 	fp := feed.(*gofeed.Feed)
+	metrics := s.freshnessMetrics(fp, time.Now())
+
+	// An empty feed says nothing about the service, not even that it is fine.
+	if len(fp.Items) == 0 {
+		log.Debugf("%s: feed has no items, reporting unknown status", s.Config.Name)
+		return append(metrics, s.statusMetrics(stateUnknown)...), nil
+	}
+
+	incidents, ignored := s.filter.split(feedIncidents(s.Parser, fp.Items))
+	metrics = append(metrics, s.feedComponentMetrics(incidents)...)
+	metrics = append(metrics, s.ignoredMetrics(ignored)...)
+	return append(metrics, s.incidentMetrics(incidents)...), nil
+}
+
+// statusMetrics reports state in service_status, one series per state.
+func (s *FeedScraper) statusMetrics(state string) []maas.Metric {
+	metrics := make([]maas.Metric, 0, len(serviceStates))
+	for _, st := range serviceStates {
+		val := 0.0
+		if state == st {
+			val = 1.0
+		}
+		metrics = append(metrics, maas.NewMetric("service_status", prometheus.GaugeValue, val, []string{s.Config.Name, s.Config.Customer, st}))
+	}
+	return metrics
+}
+
+// ignoredMetrics reports the active issues left out by the filters of the
+// service in service_ignored_issue_info, when export_ignored is set.
+func (s *FeedScraper) ignoredMetrics(incidents []*incident) []maas.Metric {
+	metrics := []maas.Metric{}
+	if !s.Config.ExportIgnored {
+		return metrics
+	}
+
+	for _, inc := range incidents {
+		if !inc.active() || inc.state == "maintenance" {
+			continue
+		}
+		metrics = append(metrics, maas.NewMetric("service_ignored_issue_info", prometheus.GaugeValue, 1, []string{s.Config.Name, s.Config.Customer, inc.serviceName, inc.region, inc.title, inc.link, inc.guid}))
+	}
+	return metrics
+}
+
+// incidentMetrics converts incidents into metrics. Every active incident other
+// than maintenance is reported in service_issue_info. service_status and the
+// finer grained service_severity and service_impact reflect the worst of them.
+func (s *FeedScraper) incidentMetrics(incidents []*incident) []maas.Metric {
+	metrics := s.lifecycleMetrics(incidents)
 
 	state := "ok"
-	var activeItem *gofeed.Item
-	scraper := s.Parser
-	var svcName, region string
-	seen := make(map[string]struct{})
-	for _, item := range fp.Items {
-		key := scraper.IncidentKey(item)
-		if key != "" {
-			if _, ok := seen[key]; ok {
-				continue
-			}
-			seen[key] = struct{}{}
+	severity := SeverityOperational
+	for _, inc := range incidents {
+		if inc.state == "maintenance" && !inc.windowStart.IsZero() {
+			labels := []string{s.Config.Name, s.Config.Customer, inc.key, inc.title}
+			metrics = append(metrics,
+				maas.NewMetric("service_maintenance_window_start_timestamp_seconds", prometheus.GaugeValue, float64(inc.windowStart.Unix()), labels),
+				maas.NewMetric("service_maintenance_window_end_timestamp_seconds", prometheus.GaugeValue, float64(inc.windowEnd.Unix()), labels),
+			)
 		}
-		_, st, active := extractServiceStatus(item)
-		if st == "resolved" {
-			state = "ok"
-			activeItem = nil
-			svcName, region = scraper.ServiceInfo(item)
-			break
+
+		if !inc.active() {
+			continue
 		}
-		if active {
-			state = st
-			activeItem = item
-			svcName, region = scraper.ServiceInfo(item)
-			break
+		if stateSeverity[inc.state] > stateSeverity[state] {
+			state = inc.state
 		}
+		if inc.severity > severity {
+			severity = inc.severity
+		}
+		if inc.state == "maintenance" {
+			continue
+		}
+		metrics = append(metrics, maas.NewMetric("service_issue_info", prometheus.GaugeValue, 1, []string{s.Config.Name, s.Config.Customer, inc.serviceName, inc.region, inc.title, inc.link, inc.guid}))
 	}
 
-	for _, st := range []string{"ok", "service_issue", "outage"} {
+	metrics = append(metrics, s.statusMetrics(state)...)
+	metrics = append(metrics, maas.NewMetric("service_severity", prometheus.GaugeValue, float64(severity), []string{s.Config.Name, s.Config.Customer}))
+	metrics = append(metrics, s.impactMetrics("service_impact", severity, s.Config.Name, s.Config.Customer)...)
+
+	return metrics
+}
+
+// impactMetrics returns one series of name per severity, set to 1 for the
+// given severity. The severity name is appended to labels.
+func (s *FeedScraper) impactMetrics(name string, severity Severity, labels ...string) []maas.Metric {
+	metrics := make([]maas.Metric, 0, len(severityNames))
+	for i, n := range severityNames {
 		val := 0.0
-		if state == st {
+		if Severity(i) == severity {
 			val = 1.0
 		}
-		metrics = append(metrics, maas.NewMetric("service_status", prometheus.GaugeValue, val, []string{s.Config.Name, s.Config.Customer, st}))
+		metrics = append(metrics, maas.NewMetric(name, prometheus.GaugeValue, val, append(append([]string{}, labels...), n)))
+	}
+	return metrics
+}
+
+// lifecycleMetrics records the incidents of the feed in the store and reports
+// when each of them started and was last updated. Incidents are counted the
+// first time their key is seen, and forgotten once they have been out of the
+// feed for longer than incidentRetention.
+func (s *FeedScraper) lifecycleMetrics(incidents []*incident) []maas.Metric {
+	metrics := []maas.Metric{}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		state, err := s.Store.Load(s.Config.Name)
+		if err != nil {
+			log.Errorf("%s: unable to load incident state: %s", s.Config.Name, err)
+			state = newServiceIncidents()
+		}
+		s.state = state
+	}
+
+	now := time.Now()
+	for _, inc := range incidents {
+		start, updated := inc.start, inc.updated
+		if inc.key != "" {
+			rec := s.record(inc, now)
+			start, updated = rec.Started, rec.Updated
+		}
+
+		if start.IsZero() {
+			continue
+		}
+		labels := []string{s.Config.Name, s.Config.Customer, inc.key, inc.state}
+		metrics = append(metrics,
+			maas.NewMetric("service_incident_start_timestamp_seconds", prometheus.GaugeValue, float64(start.Unix()), labels),
+			maas.NewMetric("service_incident_last_update_timestamp_seconds", prometheus.GaugeValue, float64(updated.Unix()), labels),
+		)
+	}
+
+	for key, rec := range s.state.Incidents {
+		if now.Sub(rec.LastSeen) > incidentRetention {
+			delete(s.state.Incidents, key)
+		}
+	}
+
+	if err := s.Store.Save(s.Config.Name, s.state); err != nil {
+		log.Errorf("%s: unable to save incident state: %s", s.Config.Name, err)
+	}
+
+	for _, st := range incidentStates {
+		metrics = append(metrics, maas.NewMetric("service_incidents_total", prometheus.CounterValue, s.state.Counts[st], []string{s.Config.Name, s.Config.Customer, st}))
+	}
+
+	return metrics
+}
+
+// record updates the stored record of an incident with what the feed shows
+// now. Start and update times only move outwards, so they survive older items
+// dropping out of the feed.
+func (s *FeedScraper) record(inc *incident, now time.Time) *IncidentRecord {
+	rec, ok := s.state.Incidents[inc.key]
+	if !ok {
+		rec = &IncidentRecord{Key: inc.key, FirstSeen: now}
+		s.state.Incidents[inc.key] = rec
+		s.state.Counts[inc.state]++
+	}
+
+	rec.LastSeen = now
+	if !inc.start.IsZero() && (rec.Started.IsZero() || inc.start.Before(rec.Started)) {
+		rec.Started = inc.start
+	}
+	if inc.updated.After(rec.Updated) {
+		rec.Updated = inc.updated
 	}
 
-	if activeItem != nil {
-		if svcName == "" && region == "" {
-			svcName, region = scraper.ServiceInfo(activeItem)
+	switch {
+	case inc.state != "resolved":
+		rec.ResolvedAt = nil
+	case rec.ResolvedAt == nil:
+		resolved := inc.time
+		if resolved.IsZero() {
+			resolved = now
 		}
-		metrics = append(metrics, maas.NewMetric("service_issue_info", prometheus.GaugeValue, 1, []string{s.Config.Name, s.Config.Customer, svcName, region, strings.TrimSpace(activeItem.Title), activeItem.Link, activeItem.GUID}))
+		rec.ResolvedAt = &resolved
 	}
+	rec.State = inc.state
 
-	return metrics, nil
+	return rec
 }
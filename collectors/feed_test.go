@@ -1,11 +1,14 @@
 package collectors
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/mbrown007/monitoring-rss-exporter/connectors"
@@ -13,6 +16,17 @@ import (
 	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 )
 
+// startExporter starts e and waits for the first scrape of every feed.
+func startExporter(t *testing.T, e *maas.Exporter) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	e.Start()
+	require.NoError(t, e.WaitReady(ctx))
+}
+
 type FeedTestSuite struct {
 	suite.Suite
 	Connector *connectors.MockHTTPConnector
@@ -35,47 +49,63 @@ func (s *FeedTestSuite) setupExporter(feedPath, url, name, provider string) {
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}), // Use port 0 for testing
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}), // Use port 0 for testing
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
 }
 
+func (s *FeedTestSuite) TestZeroIntervalDefaults() {
+	app := kingpin.New("test", "")
+	sc := NewFeedCollector(app, maas.ServiceFeed{Name: "aws", URL: "http://mock.aws/feed", Provider: "aws"})
+	sc.Flags(app)
+
+	s.Equal("@every 5m0s", sc.ScheduleSpec())
+	s.Equal([]string{"30s"}, app.GetFlag("aws.timeout").Model().Default)
+	s.Equal([]string{"30s"}, app.GetFlag("aws.jitter").Model().Default)
+}
+
 func (s *FeedTestSuite) TestAWSOutage() {
 	s.setupExporter("testdata/aws_outage.rss", "http://mock.aws/feed", "aws-test", "aws")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	expected := "# HELP aws_test_service_status Current service status\n" +
 		"# TYPE aws_test_service_status gauge\n" +
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"maintenance\"} 0\n" +
 		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"ok\"} 0\n" +
 		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"outage\"} 1\n" +
-		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"service_issue\"} 0\n"
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"service_issue\"} 0\n" +
+		"aws_test_service_status{customer=\"\",service=\"aws-test\",state=\"unknown\"} 0\n"
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "aws-test_service_status")
 	s.NoError(err)
 }
 
 func (s *FeedTestSuite) TestAzureServiceIssue() {
 	s.setupExporter("testdata/azure_issue.rss", "http://mock.azure/feed", "azure-test", "azure")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	expected := "# HELP azure_test_service_status Current service status\n" +
 		"# TYPE azure_test_service_status gauge\n" +
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"maintenance\"} 0\n" +
 		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"ok\"} 0\n" +
 		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"outage\"} 0\n" +
-		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"service_issue\"} 1\n"
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"service_issue\"} 1\n" +
+		"azure_test_service_status{customer=\"\",service=\"azure-test\",state=\"unknown\"} 0\n"
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "azure-test_service_status")
 	s.NoError(err)
 }
 
 func (s *FeedTestSuite) TestOpenAIResolved() {
 	s.setupExporter("testdata/openai_resolved.atom", "http://mock.openai/feed", "openai-test", "")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	expected := "# HELP openai_test_service_status Current service status\n" +
 		"# TYPE openai_test_service_status gauge\n" +
+		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"maintenance\"} 0\n" +
 		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"ok\"} 1\n" +
 		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"outage\"} 0\n" +
-		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"service_issue\"} 0\n"
+		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"service_issue\"} 0\n" +
+		"openai_test_service_status{customer=\"\",service=\"openai-test\",state=\"unknown\"} 0\n"
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "openai-test_service_status")
 	s.NoError(err)
 }
@@ -83,3 +113,190 @@ func (s *FeedTestSuite) TestOpenAIResolved() {
 func TestFeedSuite(t *testing.T) {
 	suite.Run(t, new(FeedTestSuite))
 }
+
+func (s *FeedTestSuite) TestClassifierOverridesKeywords() {
+	s.Connector.Responses["http://mock.cloudflare/feed"] = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Cloudflare Status</title>
+  <entry>
+    <id>tag:www.cloudflarestatus.com,2005:Incident/1</id>
+    <updated>2025-06-12T23:01:07Z</updated>
+    <title>LAX (Los Angeles) on 2025-07-03</title>
+    <content type="html">&lt;p&gt;&lt;strong&gt;Scheduled&lt;/strong&gt; - We will be monitoring traffic during the maintenance window.&lt;/p&gt;</content>
+  </entry>
+</feed>`
+
+	app := kingpin.New("test", "")
+	cfg := maas.ServiceFeed{Name: "cloudflare", URL: "http://mock.cloudflare/feed", Provider: "cloudflare", Interval: 300}
+	e, err := maas.NewExporter(app, s.Connector,
+		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	s.Require().NoError(err)
+	startExporter(s.T(), e)
+
+	expected := `
+# HELP test_cloudflare_service_status Current service status
+# TYPE test_cloudflare_service_status gauge
+test_cloudflare_service_status{customer="",service="cloudflare",state="maintenance"} 1
+test_cloudflare_service_status{customer="",service="cloudflare",state="ok"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="outage"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="service_issue"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="unknown"} 0
+`
+	s.NoError(testutil.CollectAndCompare(e, strings.NewReader(expected), "test_cloudflare_service_status", "test_cloudflare_service_issue_info"))
+}
+
+func (s *FeedTestSuite) TestConcurrentIncidents() {
+	s.Connector.Responses["http://mock.aws/concurrent"] = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Amazon Web Services Service Status</title>
+    <item>
+      <title>RESOLVED: Increased Error Rates</title>
+      <guid>https://status.aws.amazon.com/#s3-us-east-1_1749840000</guid>
+      <pubDate>Fri, 13 Jun 2025 18:40:00 PDT</pubDate>
+      <description>Amazon S3 error rates have recovered.</description>
+    </item>
+    <item>
+      <title>Service impact: Increased API Latency</title>
+      <guid>https://status.aws.amazon.com/#lambda-eu-west-1_1749836000</guid>
+      <pubDate>Fri, 13 Jun 2025 17:33:20 PDT</pubDate>
+      <description>AWS Lambda invocations are delayed.</description>
+    </item>
+    <item>
+      <title>Service impact: Increased Error Rates</title>
+      <guid>https://status.aws.amazon.com/#s3-us-east-1_1749833000</guid>
+      <pubDate>Fri, 13 Jun 2025 16:43:20 PDT</pubDate>
+      <description>Amazon S3 requests are failing.</description>
+    </item>
+    <item>
+      <title>Outage: Instance Connectivity</title>
+      <guid>https://status.aws.amazon.com/#ec2-us-east-1_1749830000</guid>
+      <pubDate>Fri, 13 Jun 2025 15:53:20 PDT</pubDate>
+      <description>Amazon EC2 instances are unreachable.</description>
+    </item>
+  </channel>
+</rss>`
+
+	app := kingpin.New("test", "")
+	cfg := maas.ServiceFeed{Name: "aws", URL: "http://mock.aws/concurrent", Provider: "aws", Interval: 300}
+	e, err := maas.NewExporter(app, s.Connector,
+		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	s.Require().NoError(err)
+	startExporter(s.T(), e)
+
+	expected := `
+# HELP test_aws_service_issue_info Details for active service issues
+# TYPE test_aws_service_issue_info gauge
+test_aws_service_issue_info{customer="",guid="https://status.aws.amazon.com/#ec2-us-east-1_1749830000",link="",region="US East (N. Virginia)",service="aws",service_name="Amazon EC2",title="Outage: Instance Connectivity"} 1
+test_aws_service_issue_info{customer="",guid="https://status.aws.amazon.com/#lambda-eu-west-1_1749836000",link="",region="Europe (Ireland)",service="aws",service_name="AWS Lambda",title="Service impact: Increased API Latency"} 1
+# HELP test_aws_service_impact Current service impact
+# TYPE test_aws_service_impact gauge
+test_aws_service_impact{customer="",impact="degraded_performance",service="aws"} 0
+test_aws_service_impact{customer="",impact="major_outage",service="aws"} 1
+test_aws_service_impact{customer="",impact="operational",service="aws"} 0
+test_aws_service_impact{customer="",impact="partial_outage",service="aws"} 0
+test_aws_service_impact{customer="",impact="under_maintenance",service="aws"} 0
+# HELP test_aws_service_severity Severity of the most severe active incident, from 0 (operational) to 4 (major outage)
+# TYPE test_aws_service_severity gauge
+test_aws_service_severity{customer="",service="aws"} 4
+# HELP test_aws_service_status Current service status
+# TYPE test_aws_service_status gauge
+test_aws_service_status{customer="",service="aws",state="maintenance"} 0
+test_aws_service_status{customer="",service="aws",state="ok"} 0
+test_aws_service_status{customer="",service="aws",state="outage"} 1
+test_aws_service_status{customer="",service="aws",state="service_issue"} 0
+test_aws_service_status{customer="",service="aws",state="unknown"} 0
+`
+	s.NoError(testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"test_aws_service_status", "test_aws_service_issue_info", "test_aws_service_severity", "test_aws_service_impact"))
+}
+
+func (s *FeedTestSuite) TestMaintenanceWindow() {
+	s.setupExporter("testdata/cloudflare_maintenance.atom", "http://mock.cloudflare/maintenance", "cloudflare", "cloudflare")
+	startExporter(s.T(), s.Exporter)
+
+	// The window lies in the past, so the service is no longer in maintenance.
+	expected := `
+# HELP test_cloudflare_service_maintenance_window_end_timestamp_seconds Scheduled end of announced maintenance
+# TYPE test_cloudflare_service_maintenance_window_end_timestamp_seconds gauge
+test_cloudflare_service_maintenance_window_end_timestamp_seconds{customer="",incident="tag:www.cloudflarestatus.com,2005:Incident/25460322",service="cloudflare",title="LAX (Los Angeles) on 2025-07-03"} 1.7515206e+09
+# HELP test_cloudflare_service_maintenance_window_start_timestamp_seconds Scheduled start of announced maintenance
+# TYPE test_cloudflare_service_maintenance_window_start_timestamp_seconds gauge
+test_cloudflare_service_maintenance_window_start_timestamp_seconds{customer="",incident="tag:www.cloudflarestatus.com,2005:Incident/25460322",service="cloudflare",title="LAX (Los Angeles) on 2025-07-03"} 1.7515116e+09
+# HELP test_cloudflare_service_status Current service status
+# TYPE test_cloudflare_service_status gauge
+test_cloudflare_service_status{customer="",service="cloudflare",state="maintenance"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="ok"} 1
+test_cloudflare_service_status{customer="",service="cloudflare",state="outage"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="service_issue"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="unknown"} 0
+`
+	s.NoError(testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected),
+		"test_cloudflare_service_status",
+		"test_cloudflare_service_maintenance_window_start_timestamp_seconds",
+		"test_cloudflare_service_maintenance_window_end_timestamp_seconds",
+	))
+}
+
+func (s *FeedTestSuite) TestFilteredIncidents() {
+	s.Connector.Responses["http://mock.aws/filtered"] = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Amazon Web Services Service Status</title>
+    <item>
+      <title>Service impact: Increased API Latency</title>
+      <guid>https://status.aws.amazon.com/#lambda-eu-west-1_1749836000</guid>
+      <pubDate>Fri, 13 Jun 2025 17:33:20 PDT</pubDate>
+      <description>AWS Lambda invocations are delayed.</description>
+    </item>
+    <item>
+      <title>Outage: Instance Connectivity</title>
+      <guid>https://status.aws.amazon.com/#ec2-us-east-1_1749830000</guid>
+      <pubDate>Fri, 13 Jun 2025 15:53:20 PDT</pubDate>
+      <description>Amazon EC2 instances are unreachable.</description>
+    </item>
+  </channel>
+</rss>`
+
+	app := kingpin.New("test", "")
+	cfg := maas.ServiceFeed{
+		Name:          "aws",
+		URL:           "http://mock.aws/filtered",
+		Provider:      "aws",
+		Interval:      300,
+		Include:       &maas.IncidentFilter{Regions: []string{"Europe (Ireland)"}},
+		ExportIgnored: true,
+	}
+	e, err := maas.NewExporter(app, s.Connector,
+		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	s.Require().NoError(err)
+	startExporter(s.T(), e)
+
+	// The EC2 outage is outside the included region and leaves the status alone.
+	expected := `
+# HELP test_aws_service_ignored_issue_info Details for active service issues left out by the include and exclude filters
+# TYPE test_aws_service_ignored_issue_info gauge
+test_aws_service_ignored_issue_info{customer="",guid="https://status.aws.amazon.com/#ec2-us-east-1_1749830000",link="",region="US East (N. Virginia)",service="aws",service_name="Amazon EC2",title="Outage: Instance Connectivity"} 1
+# HELP test_aws_service_issue_info Details for active service issues
+# TYPE test_aws_service_issue_info gauge
+test_aws_service_issue_info{customer="",guid="https://status.aws.amazon.com/#lambda-eu-west-1_1749836000",link="",region="Europe (Ireland)",service="aws",service_name="AWS Lambda",title="Service impact: Increased API Latency"} 1
+# HELP test_aws_service_status Current service status
+# TYPE test_aws_service_status gauge
+test_aws_service_status{customer="",service="aws",state="maintenance"} 0
+test_aws_service_status{customer="",service="aws",state="ok"} 0
+test_aws_service_status{customer="",service="aws",state="outage"} 0
+test_aws_service_status{customer="",service="aws",state="service_issue"} 1
+test_aws_service_status{customer="",service="aws",state="unknown"} 0
+`
+	s.NoError(testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"test_aws_service_status", "test_aws_service_issue_info", "test_aws_service_ignored_issue_info"))
+}
@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+// serviceFilter decides which incidents of a service count towards its status,
+// from the include and exclude blocks of the service.
+type serviceFilter struct {
+	include *incidentFilter
+	exclude *incidentFilter
+}
+
+type incidentFilter struct {
+	services []string
+	regions  []string
+	title    *regexp.Regexp
+}
+
+func newServiceFilter(cfg maas.ServiceFeed) (*serviceFilter, error) {
+	include, err := newIncidentFilter(cfg.Include)
+	if err != nil {
+		return nil, fmt.Errorf("include: %w", err)
+	}
+	exclude, err := newIncidentFilter(cfg.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("exclude: %w", err)
+	}
+	return &serviceFilter{include: include, exclude: exclude}, nil
+}
+
+// newIncidentFilter compiles f. A missing or empty filter returns nil.
+func newIncidentFilter(f *maas.IncidentFilter) (*incidentFilter, error) {
+	if f == nil || (len(f.Services) == 0 && len(f.Regions) == 0 && f.Title == "") {
+		return nil, nil
+	}
+
+	m := &incidentFilter{}
+	for _, svc := range f.Services {
+		m.services = append(m.services, strings.ToLower(strings.TrimSpace(svc)))
+	}
+	for _, region := range f.Regions {
+		m.regions = append(m.regions, strings.ToLower(strings.TrimSpace(region)))
+	}
+	if f.Title != "" {
+		re, err := regexp.Compile(f.Title)
+		if err != nil {
+			return nil, fmt.Errorf("title: %w", err)
+		}
+		m.title = re
+	}
+
+	return m, nil
+}
+
+// match reports whether inc satisfies every criterion of the filter. Services
+// are compared with the service name and the components of the incident,
+// regions with its region and the component groups, both case-insensitively.
+func (f *incidentFilter) match(inc *incident) bool {
+	if len(f.services) > 0 {
+		names := []string{inc.serviceName}
+		for _, c := range inc.components {
+			names = append(names, c.Name)
+		}
+		if !matchAny(f.services, names) {
+			return false
+		}
+	}
+	if len(f.regions) > 0 {
+		regions := []string{inc.region}
+		for _, c := range inc.components {
+			regions = append(regions, c.Group)
+		}
+		if !matchAny(f.regions, regions) {
+			return false
+		}
+	}
+	if f.title != nil && !f.title.MatchString(inc.title) {
+		return false
+	}
+	return true
+}
+
+func matchAny(want, values []string) bool {
+	for _, v := range values {
+		if v != "" && contains(want, strings.ToLower(strings.TrimSpace(v))) {
+			return true
+		}
+	}
+	return false
+}
+
+// split separates the incidents matching the include filter, when there is
+// one, and not matching the exclude filter from the ignored ones.
+func (f *serviceFilter) split(incidents []*incident) (kept, ignored []*incident) {
+	if f == nil || (f.include == nil && f.exclude == nil) {
+		return incidents, nil
+	}
+
+	for _, inc := range incidents {
+		if (f.include != nil && !f.include.match(inc)) || (f.exclude != nil && f.exclude.match(inc)) {
+			ignored = append(ignored, inc)
+			continue
+		}
+		kept = append(kept, inc)
+	}
+	return kept, ignored
+}
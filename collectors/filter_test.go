@@ -0,0 +1,84 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestServiceFilterSplit(t *testing.T) {
+	ec2 := &incident{key: "ec2", title: "Outage: Instance Connectivity", serviceName: "Amazon EC2", region: "US East (N. Virginia)"}
+	lambda := &incident{key: "lambda", title: "Service impact: Increased API Latency", serviceName: "AWS Lambda", region: "Europe (Ireland)"}
+	s3 := &incident{key: "s3", title: "Informational message: Billing delays", serviceName: "Amazon S3", region: "Europe (Ireland)"}
+	api := &incident{key: "api", title: "Elevated errors", serviceName: "API, Dashboard", components: []Component{{Name: "API"}, {Name: "Dashboard"}}}
+	all := []*incident{ec2, lambda, s3, api}
+
+	tests := []struct {
+		name    string
+		cfg     maas.ServiceFeed
+		kept    []*incident
+		ignored []*incident
+	}{
+		{
+			name: "No filter",
+			kept: all,
+		},
+		{
+			name:    "Include services",
+			cfg:     maas.ServiceFeed{Include: &maas.IncidentFilter{Services: []string{"amazon ec2", "AWS Lambda"}}},
+			kept:    []*incident{ec2, lambda},
+			ignored: []*incident{s3, api},
+		},
+		{
+			name:    "Include service and region",
+			cfg:     maas.ServiceFeed{Include: &maas.IncidentFilter{Services: []string{"Amazon EC2", "Amazon S3"}, Regions: []string{"Europe (Ireland)"}}},
+			kept:    []*incident{s3},
+			ignored: []*incident{ec2, lambda, api},
+		},
+		{
+			name:    "Include component",
+			cfg:     maas.ServiceFeed{Include: &maas.IncidentFilter{Services: []string{"API"}}},
+			kept:    []*incident{api},
+			ignored: []*incident{ec2, lambda, s3},
+		},
+		{
+			name:    "Exclude title",
+			cfg:     maas.ServiceFeed{Exclude: &maas.IncidentFilter{Title: `(?i)^informational message:`}},
+			kept:    []*incident{ec2, lambda, api},
+			ignored: []*incident{s3},
+		},
+		{
+			name: "Include and exclude",
+			cfg: maas.ServiceFeed{
+				Include: &maas.IncidentFilter{Regions: []string{"Europe (Ireland)"}},
+				Exclude: &maas.IncidentFilter{Services: []string{"Amazon S3"}},
+			},
+			kept:    []*incident{lambda},
+			ignored: []*incident{ec2, s3, api},
+		},
+		{
+			name: "Empty filters",
+			cfg:  maas.ServiceFeed{Include: &maas.IncidentFilter{}, Exclude: &maas.IncidentFilter{}},
+			kept: all,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newServiceFilter(tt.cfg)
+			require.NoError(t, err)
+
+			kept, ignored := f.split(all)
+			assert.Equal(t, tt.kept, kept)
+			assert.Equal(t, tt.ignored, ignored)
+		})
+	}
+}
+
+func TestServiceFilterInvalidTitle(t *testing.T) {
+	_, err := newServiceFilter(maas.ServiceFeed{Exclude: &maas.IncidentFilter{Title: "("}})
+	assert.ErrorContains(t, err, "exclude: title:")
+}
@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/prometheus/client_golang/prometheus"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+// newestItem returns the publication time of the most recently published
+// item, falling back to its update time, or zero when no item has either.
+func newestItem(items []*gofeed.Item) time.Time {
+	var newest time.Time
+	for _, item := range items {
+		t := itemTime(item)
+		if item.PublishedParsed != nil {
+			t = *item.PublishedParsed
+		}
+		if t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// freshnessMetrics reports how recently the publisher updated the feed, so
+// that a feed which silently stopped updating can be told from a quiet one.
+// Timestamps missing from the feed are not reported.
+func (s *FeedScraper) freshnessMetrics(feed *gofeed.Feed, now time.Time) []maas.Metric {
+	labels := []string{s.Config.Name, s.Config.Customer}
+
+	metrics := []maas.Metric{
+		maas.NewMetric("feed_items", prometheus.GaugeValue, float64(len(feed.Items)), labels),
+	}
+
+	if feed.UpdatedParsed != nil {
+		metrics = append(metrics, maas.NewMetric("feed_updated_timestamp_seconds", prometheus.GaugeValue, float64(feed.UpdatedParsed.Unix()), labels))
+	}
+
+	if newest := newestItem(feed.Items); !newest.IsZero() {
+		metrics = append(metrics, maas.NewMetric("feed_newest_item_timestamp_seconds", prometheus.GaugeValue, float64(newest.Unix()), labels))
+		metrics = append(metrics, maas.NewMetric("feed_newest_item_age_seconds", prometheus.GaugeValue, now.Sub(newest).Seconds(), labels))
+	}
+
+	return metrics
+}
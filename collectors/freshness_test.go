@@ -0,0 +1,56 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestNewestItem(t *testing.T) {
+	published := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	updated := published.Add(2 * time.Hour)
+	older := published.Add(-time.Hour)
+
+	assert.True(t, newestItem(nil).IsZero())
+	assert.True(t, newestItem([]*gofeed.Item{{Title: "No date"}}).IsZero())
+
+	// The publication time wins over a later update of the same item.
+	assert.Equal(t, published, newestItem([]*gofeed.Item{
+		{PublishedParsed: &older},
+		{PublishedParsed: &published, UpdatedParsed: &updated},
+	}))
+	assert.Equal(t, updated, newestItem([]*gofeed.Item{
+		{PublishedParsed: &published},
+		{UpdatedParsed: &updated},
+	}))
+}
+
+func TestFreshnessMetrics(t *testing.T) {
+	s := NewFeedScraper(maas.ServiceFeed{Name: "acme", Customer: "corp", URL: "http://mock.acme/feed"})
+	labels := []string{"acme", "corp"}
+
+	published := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	updated := published.Add(time.Minute)
+	now := published.Add(time.Hour)
+
+	feed := &gofeed.Feed{
+		UpdatedParsed: &updated,
+		Items:         []*gofeed.Item{{PublishedParsed: &published}, {Title: "No date"}},
+	}
+	assert.Equal(t, []maas.Metric{
+		maas.NewMetric("feed_items", prometheus.GaugeValue, 2, labels),
+		maas.NewMetric("feed_updated_timestamp_seconds", prometheus.GaugeValue, float64(updated.Unix()), labels),
+		maas.NewMetric("feed_newest_item_timestamp_seconds", prometheus.GaugeValue, float64(published.Unix()), labels),
+		maas.NewMetric("feed_newest_item_age_seconds", prometheus.GaugeValue, 3600, labels),
+	}, s.freshnessMetrics(feed, now))
+
+	// Timestamps the feed does not carry are left out.
+	assert.Equal(t, []maas.Metric{
+		maas.NewMetric("feed_items", prometheus.GaugeValue, 0, labels),
+	}, s.freshnessMetrics(&gofeed.Feed{}, now))
+}
@@ -35,7 +35,7 @@ func (s *GCPIntegrationTestSuite) setupExporter(feedPath, url, name, provider st
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
@@ -43,14 +43,16 @@ func (s *GCPIntegrationTestSuite) setupExporter(feedPath, url, name, provider st
 
 func (s *GCPIntegrationTestSuite) TestGCPComputeEngineIssue() {
 	s.setupExporter("testdata/gcp_compute_engine_issue.atom", "http://mock.gcp/feed", "gcp-test", "gcp")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status metrics
 	expected := "# HELP gcp_test_service_status Current service status\n" +
 		"# TYPE gcp_test_service_status gauge\n" +
+		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"maintenance\"} 0\n" +
 		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"ok\"} 0\n" +
 		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"outage\"} 0\n" +
-		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"service_issue\"} 1\n"
+		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"service_issue\"} 1\n" +
+		"gcp_test_service_status{customer=\"\",service=\"gcp-test\",state=\"unknown\"} 0\n"
 	
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "gcp-test_service_status")
 	s.NoError(err)
@@ -60,14 +62,16 @@ func (s *GCPIntegrationTestSuite) TestGCPComputeEngineIssue() {
 
 func (s *GCPIntegrationTestSuite) TestGCPMultipleServices() {
 	s.setupExporter("testdata/gcp_multiple_services.atom", "http://mock.gcp/multi", "gcp-multi", "gcp")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows incident
 	expected := "# HELP gcp_multi_service_status Current service status\n" +
 		"# TYPE gcp_multi_service_status gauge\n" +
+		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"maintenance\"} 0\n" +
 		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"ok\"} 0\n" +
 		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"outage\"} 0\n" +
-		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"service_issue\"} 1\n"
+		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"service_issue\"} 1\n" +
+		"gcp_multi_service_status{customer=\"\",service=\"gcp-multi\",state=\"unknown\"} 0\n"
 	
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "gcp-multi_service_status")
 	s.NoError(err)
@@ -4,9 +4,16 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("gcp", nil, func(maas.ServiceFeed) Scraper {
+		return enhancedGCPParser{}
+	})
+}
+
 // Enhanced GCP parser with service and region extraction capabilities
 type enhancedGCPParser struct{}
 
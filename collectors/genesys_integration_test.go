@@ -35,7 +35,7 @@ func (s *GenesysIntegrationTestSuite) setupExporter(feedPath, url, name, provide
 	e, err := maas.NewExporter(app, s.Connector,
 		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
 		maas.WithLabels(&maas.MockLabels{}),
-		maas.WithArgs([]string{"--web.listen-port=0"}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
 	)
 	s.Require().NoError(err)
 	s.Exporter = e
@@ -43,14 +43,16 @@ func (s *GenesysIntegrationTestSuite) setupExporter(feedPath, url, name, provide
 
 func (s *GenesysIntegrationTestSuite) TestGenysysTTSIssue() {
 	s.setupExporter("testdata/genesys_tts_issue.atom", "http://mock.genesys/tts", "genesys-test", "genesyscloud")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status metrics
 	expected := "# HELP genesys_test_service_status Current service status\n" +
 		"# TYPE genesys_test_service_status gauge\n" +
+		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"maintenance\"} 0\n" +
 		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"ok\"} 0\n" +
 		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"outage\"} 0\n" +
-		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"service_issue\"} 1\n"
+		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"service_issue\"} 1\n" +
+		"genesys_test_service_status{customer=\"\",service=\"genesys-test\",state=\"unknown\"} 0\n"
 	
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "genesys-test_service_status")
 	s.NoError(err)
@@ -58,14 +60,16 @@ func (s *GenesysIntegrationTestSuite) TestGenysysTTSIssue() {
 
 func (s *GenesysIntegrationTestSuite) TestGenesysWhatsAppMultiRegion() {
 	s.setupExporter("testdata/genesys_whatsapp_multi_region.atom", "http://mock.genesys/whatsapp", "genesys-whatsapp", "genesyscloud")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows incident
 	expected := "# HELP genesys_whatsapp_service_status Current service status\n" +
 		"# TYPE genesys_whatsapp_service_status gauge\n" +
+		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"maintenance\"} 0\n" +
 		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"ok\"} 0\n" +
 		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"outage\"} 0\n" +
-		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"service_issue\"} 1\n"
+		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"service_issue\"} 1\n" +
+		"genesys_whatsapp_service_status{customer=\"\",service=\"genesys-whatsapp\",state=\"unknown\"} 0\n"
 	
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "genesys-whatsapp_service_status")
 	s.NoError(err)
@@ -73,14 +77,16 @@ func (s *GenesysIntegrationTestSuite) TestGenesysWhatsAppMultiRegion() {
 
 func (s *GenesysIntegrationTestSuite) TestGenesysAnalyticsResolved() {
 	s.setupExporter("testdata/genesys_analytics_resolved.atom", "http://mock.genesys/analytics", "genesys-analytics", "genesyscloud")
-	s.Exporter.Start()
+	startExporter(s.T(), s.Exporter)
 
 	// Verify service status shows resolved (ok)
 	expected := "# HELP genesys_analytics_service_status Current service status\n" +
 		"# TYPE genesys_analytics_service_status gauge\n" +
+		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"maintenance\"} 0\n" +
 		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"ok\"} 1\n" +
 		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"outage\"} 0\n" +
-		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"service_issue\"} 0\n"
+		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"service_issue\"} 0\n" +
+		"genesys_analytics_service_status{customer=\"\",service=\"genesys-analytics\",state=\"unknown\"} 0\n"
 	
 	err := testutil.CollectAndCompare(s.Exporter, strings.NewReader(expected), "genesys-analytics_service_status")
 	s.NoError(err)
@@ -4,9 +4,16 @@ import (
 	"regexp"
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("genesyscloud", []string{"genesys-cloud", "genesys"}, func(maas.ServiceFeed) Scraper {
+		return genesysParser{}
+	})
+}
+
 // Genesys Cloud parser for extracting service and region information
 type genesysParser struct{}
 
@@ -28,6 +35,30 @@ func (genesysParser) IncidentKey(item *gofeed.Item) string {
 	return strings.TrimSpace(item.Title)
 }
 
+// ClassifyStatus uses the Statuspage update tags and falls back to the
+// Genesys-specific text detection with a lower confidence.
+func (genesysParser) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	if state, ok := statuspageStatus(item); ok {
+		return state, 1
+	}
+	if _, state, _ := extractGenesysStatus(item); state != "" {
+		return state, 0.5
+	}
+	return "", 0
+}
+
+// ClassifySeverity reads the impact from the title and latest Statuspage
+// update, such as "Partial outage" or "Degraded performance".
+func (genesysParser) ClassifySeverity(item *gofeed.Item) (Severity, bool) {
+	return statuspageSeverity(item)
+}
+
+// Components reports the detected service as the affected component, grouped
+// by the detected region.
+func (p genesysParser) Components(item *gofeed.Item) []Component {
+	return serviceInfoComponents(p, item)
+}
+
 // extractGenesysService attempts to identify the Genesys Cloud service affected
 func extractGenesysService(item *gofeed.Item) string {
 	content := strings.ToLower(item.Title + " " + item.Description + " " + item.Content)
@@ -170,6 +201,8 @@ func extractGenesysStatus(item *gofeed.Item) (service string, state string, acti
 		switch {
 		case strings.Contains(content, "RESOLVED"):
 			state = "resolved"
+		case isMaintenance(content):
+			state = "maintenance"
 		case strings.Contains(content, "OUTAGE") || strings.Contains(content, "MAJOR OUTAGE"):
 			state = "outage"
 		case strings.Contains(content, "ELEVATED ERROR RATES") ||
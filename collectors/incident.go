@@ -0,0 +1,128 @@
+package collectors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// serviceStates are the values of the state label of service_status.
+// unknown is reported when the status of the service cannot be told: the feed
+// cannot be fetched or parsed, or has no items.
+var serviceStates = []string{"ok", "maintenance", "service_issue", "outage", stateUnknown}
+
+const stateUnknown = "unknown"
+
+// stateSeverity orders states from least to most severe.
+var stateSeverity = map[string]int{
+	"ok":            0,
+	"maintenance":   1,
+	"service_issue": 2,
+	"outage":        3,
+}
+
+// incidentStates are the states an incident can be in.
+var incidentStates = []string{"maintenance", "service_issue", "outage", "resolved"}
+
+// incident is the latest known update of one incident in a feed. start and
+// updated are the times of its oldest and newest items. Maintenance carries
+// its scheduled window when the announcement states one, and is ongoing when
+// the vendor reports it as in progress.
+type incident struct {
+	key     string
+	state   string
+	item    *gofeed.Item
+	time    time.Time
+	start   time.Time
+	updated time.Time
+
+	title       string
+	link        string
+	guid        string
+	serviceName string
+	region      string
+	components  []Component
+
+	severity Severity
+
+	windowStart time.Time
+	windowEnd   time.Time
+	ongoing     bool
+}
+
+// active reports whether the incident affects the service now. Maintenance
+// with a known window is only active within it, unless it is ongoing.
+func (i *incident) active() bool {
+	if i.state == "maintenance" && !i.ongoing && !i.windowStart.IsZero() {
+		now := time.Now()
+		return !now.Before(i.windowStart) && now.Before(i.windowEnd)
+	}
+	return i.state != "" && i.state != "resolved"
+}
+
+// feedIncidents groups the items of a feed by incident key and keeps the most
+// recent classified item of each incident. Items without a timestamp, or with
+// the same one, keep feed order, where the first item is the newest. Items
+// without an incident key are incidents of their own. Incidents are returned
+// in the order they first appear in the feed.
+func feedIncidents(scraper Scraper, items []*gofeed.Item) []*incident {
+	incidents := []*incident{}
+	byKey := make(map[string]*incident)
+
+	for _, item := range items {
+		st, _ := itemStatus(scraper, item)
+		if st == "" {
+			continue
+		}
+
+		t := itemTime(item)
+		inc := &incident{key: scraper.IncidentKey(item), state: st, item: item, time: t, start: t, updated: t}
+		if inc.key == "" {
+			incidents = append(incidents, inc)
+			continue
+		}
+
+		existing, ok := byKey[inc.key]
+		if !ok {
+			byKey[inc.key] = inc
+			incidents = append(incidents, inc)
+			continue
+		}
+		if !inc.start.IsZero() && (existing.start.IsZero() || inc.start.Before(existing.start)) {
+			existing.start = inc.start
+		}
+		if inc.updated.After(existing.updated) {
+			existing.updated = inc.updated
+		}
+		if !existing.time.IsZero() && inc.time.After(existing.time) {
+			existing.state, existing.item, existing.time = inc.state, inc.item, inc.time
+		}
+	}
+
+	for _, inc := range incidents {
+		inc.title, inc.link, inc.guid = strings.TrimSpace(inc.item.Title), inc.item.Link, inc.item.GUID
+		inc.serviceName, inc.region = scraper.ServiceInfo(inc.item)
+		if r, ok := scraper.(ComponentReporter); ok {
+			inc.components = r.Components(inc.item)
+		}
+		inc.severity = itemSeverity(scraper, inc.item, inc.state)
+		if inc.state == "maintenance" {
+			inc.windowStart, inc.windowEnd, _ = maintenanceWindow(inc.item)
+		}
+	}
+
+	return incidents
+}
+
+// itemTime returns when an item was last updated, falling back to its
+// publication time.
+func itemTime(item *gofeed.Item) time.Time {
+	if item.UpdatedParsed != nil {
+		return *item.UpdatedParsed
+	}
+	if item.PublishedParsed != nil {
+		return *item.PublishedParsed
+	}
+	return time.Time{}
+}
@@ -0,0 +1,108 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestFeedIncidentsLatestUpdateWins(t *testing.T) {
+	older := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	items := []*gofeed.Item{
+		{GUID: "a", Title: "Service issue: investigating", PublishedParsed: &older},
+		{GUID: "a", Title: "RESOLVED", PublishedParsed: &newer},
+		{GUID: "b", Title: "Major outage"},
+		{GUID: "b", Title: "RESOLVED"},
+		{Title: "Nothing to report"},
+	}
+
+	incidents := feedIncidents(genericParser{}, items)
+	assert.Len(t, incidents, 2)
+	assert.Equal(t, "a", incidents[0].key)
+	assert.Equal(t, "resolved", incidents[0].state)
+	assert.False(t, incidents[0].active())
+	assert.Equal(t, "b", incidents[1].key)
+	assert.Equal(t, "outage", incidents[1].state)
+	assert.True(t, incidents[1].active())
+}
+
+func TestFeedIncidentsStartAndLastUpdate(t *testing.T) {
+	first := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	second := first.Add(30 * time.Minute)
+	third := first.Add(time.Hour)
+
+	items := []*gofeed.Item{
+		{GUID: "a", Title: "Monitoring", PublishedParsed: &second},
+		{GUID: "a", Title: "Resolved", UpdatedParsed: &third},
+		{GUID: "a", Title: "Investigating", PublishedParsed: &first},
+	}
+
+	incidents := feedIncidents(genericParser{}, items)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, "resolved", incidents[0].state)
+	assert.Equal(t, first, incidents[0].start)
+	assert.Equal(t, third, incidents[0].updated)
+}
+
+func TestFeedScraperCountsNewIncidents(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{}}
+	s := NewFeedScraper(maas.ServiceFeed{Name: "acme", URL: "http://mock.acme/feed"})
+
+	outage := `<item><guid>outage-1</guid><title>Major outage</title></item>`
+	issue := `<item><guid>issue-1</guid><title>Service issue: elevated errors</title></item>`
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(outage)
+	_, err := s.Scrape(connector)
+	assert.NoError(t, err)
+	_, err = s.Scrape(connector)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, s.state.Counts["outage"])
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(issue)
+	_, err = s.Scrape(connector)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, s.state.Counts["service_issue"])
+
+	// The outage dropped out of the feed but is still remembered.
+	connector.Responses["http://mock.acme/feed"] = testFeed(issue, outage)
+	_, err = s.Scrape(connector)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, s.state.Counts["outage"])
+	assert.Len(t, s.state.Incidents, 2)
+}
+
+func TestFeedScraperResolvedAt(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{}}
+	s := NewFeedScraper(maas.ServiceFeed{Name: "acme", URL: "http://mock.acme/feed"})
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(
+		`<item><guid>outage-1</guid><title>Major outage</title><pubDate>Fri, 13 Jun 2025 09:00:00 GMT</pubDate></item>`)
+	_, err := s.Scrape(connector)
+	assert.NoError(t, err)
+	assert.Nil(t, s.state.Incidents["outage-1"].ResolvedAt)
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(
+		`<item><guid>outage-1</guid><title>RESOLVED: Major outage</title><pubDate>Fri, 13 Jun 2025 10:30:00 GMT</pubDate></item>`)
+	_, err = s.Scrape(connector)
+	assert.NoError(t, err)
+
+	rec := s.state.Incidents["outage-1"]
+	assert.Equal(t, "resolved", rec.State)
+	assert.Equal(t, time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC), rec.Started.UTC())
+	if assert.NotNil(t, rec.ResolvedAt) {
+		assert.Equal(t, time.Date(2025, 6, 13, 10, 30, 0, 0, time.UTC), rec.ResolvedAt.UTC())
+	}
+}
+
+func testFeed(items ...string) string {
+	return `<?xml version="1.0"?><rss version="2.0"><channel><title>Acme</title>` +
+		strings.Join(items, "") + `</channel></rss>`
+}
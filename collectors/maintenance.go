@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// maintenanceKeywords mark announcements of planned work.
+var maintenanceKeywords = []string{
+	"SCHEDULED MAINTENANCE",
+	"PLANNED MAINTENANCE",
+	"MAINTENANCE WINDOW",
+	"THIS IS A SCHEDULED EVENT",
+}
+
+// isMaintenance reports whether upper case text announces maintenance.
+func isMaintenance(text string) bool {
+	for _, k := range maintenanceKeywords {
+		if strings.Contains(text, k) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// statuspageWindow matches the banner of Statuspage maintenance, such as
+	// "THIS IS A SCHEDULED EVENT Jul 3, 03:00 - 05:30 UTC" or
+	// "THIS IS A SCHEDULED EVENT Jul 3, 22:00 - Jul 4, 02:00 UTC".
+	statuspageWindow = regexp.MustCompile(`(?i)scheduled event\s+([a-z]{3} \d{1,2}), (\d{1,2}:\d{2})\s*-\s*(?:([a-z]{3} \d{1,2}), )?(\d{1,2}:\d{2})\s*UTC`)
+	// dateWindow matches "2025-07-03 between 03:00 and 05:30 UTC".
+	dateWindow = regexp.MustCompile(`(?i)(\d{4}-\d{2}-\d{2}) between (\d{1,2}:\d{2}) and (\d{1,2}:\d{2}) UTC`)
+)
+
+// maintenanceWindow returns the scheduled start and end of a maintenance
+// item. ok is false when the item does not state its window in a known format.
+func maintenanceWindow(item *gofeed.Item) (start, end time.Time, ok bool) {
+	text := strings.Join([]string{item.Title, item.Description, item.Content}, " ")
+
+	if m := statuspageWindow.FindStringSubmatch(text); m != nil {
+		// The banner has no year, so take the one of the announcement and
+		// move windows that would lie long before it into the next year.
+		ref := itemTime(item)
+		if ref.IsZero() {
+			ref = time.Now()
+		}
+		ref = ref.UTC()
+
+		endDay := m[3]
+		if endDay == "" {
+			endDay = m[1]
+		}
+		start, err := parseWindowTime(m[1], m[2], ref.Year())
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		end, err := parseWindowTime(endDay, m[4], ref.Year())
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		if start.Before(ref.AddDate(0, -6, 0)) {
+			start, end = start.AddDate(1, 0, 0), end.AddDate(1, 0, 0)
+		}
+		return start, spanMidnight(start, end), true
+	}
+
+	if m := dateWindow.FindStringSubmatch(text); m != nil {
+		start, err := time.Parse("2006-01-02 15:04", m[1]+" "+m[2])
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		end, err := time.Parse("2006-01-02 15:04", m[1]+" "+m[3])
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		return start, spanMidnight(start, end), true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+func parseWindowTime(day, clock string, year int) (time.Time, error) {
+	day = strings.ToUpper(day[:1]) + strings.ToLower(day[1:])
+	t, err := time.Parse("Jan 2 15:04", day+" "+clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(year-t.Year(), 0, 0), nil
+}
+
+// spanMidnight moves an end time that lies before the start to the next day.
+func spanMidnight(start, end time.Time) time.Time {
+	if end.Before(start) {
+		return end.Add(24 * time.Hour)
+	}
+	return end
+}
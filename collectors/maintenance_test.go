@@ -0,0 +1,80 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	updated := time.Date(2025, 6, 12, 23, 1, 7, 0, time.UTC)
+	december := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		item  *gofeed.Item
+		start time.Time
+		end   time.Time
+		ok    bool
+	}{
+		{
+			name:  "Statuspage banner",
+			item:  &gofeed.Item{Content: "<p><strong>THIS IS A SCHEDULED EVENT Jul 3, 03:00 - 05:30 UTC</strong></p>", UpdatedParsed: &updated},
+			start: time.Date(2025, 7, 3, 3, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 7, 3, 5, 30, 0, 0, time.UTC),
+			ok:    true,
+		},
+		{
+			name:  "Statuspage banner spanning days",
+			item:  &gofeed.Item{Content: "<strong>THIS IS A SCHEDULED EVENT Jul 3, 22:00 - Jul 4, 02:00 UTC</strong>", UpdatedParsed: &updated},
+			start: time.Date(2025, 7, 3, 22, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 7, 4, 2, 0, 0, 0, time.UTC),
+			ok:    true,
+		},
+		{
+			name:  "Statuspage banner in the next year",
+			item:  &gofeed.Item{Content: "<strong>THIS IS A SCHEDULED EVENT Jan 5, 01:00 - 03:00 UTC</strong>", UpdatedParsed: &december},
+			start: time.Date(2026, 1, 5, 1, 0, 0, 0, time.UTC),
+			end:   time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC),
+			ok:    true,
+		},
+		{
+			name:  "Date with time range across midnight",
+			item:  &gofeed.Item{Description: "Scheduled maintenance in FRA datacenter on 2025-07-03 between 23:00 and 01:00 UTC."},
+			start: time.Date(2025, 7, 3, 23, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 7, 4, 1, 0, 0, 0, time.UTC),
+			ok:    true,
+		},
+		{
+			name: "No window",
+			item: &gofeed.Item{Description: "Scheduled maintenance from 2:00 AM to 4:00 AM UTC on Saturday."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := maintenanceWindow(tt.item)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.start.Equal(start), "start %s", start)
+			assert.True(t, tt.end.Equal(end), "end %s", end)
+		})
+	}
+}
+
+func TestMaintenanceActiveWithinWindow(t *testing.T) {
+	now := time.Now()
+
+	inc := &incident{state: "maintenance"}
+	assert.True(t, inc.active(), "maintenance without a window")
+
+	inc.windowStart, inc.windowEnd = now.Add(-time.Hour), now.Add(time.Hour)
+	assert.True(t, inc.active())
+
+	inc.windowStart, inc.windowEnd = now.Add(time.Hour), now.Add(2*time.Hour)
+	assert.False(t, inc.active(), "upcoming maintenance")
+
+	inc.windowStart, inc.windowEnd = now.Add(-2*time.Hour), now.Add(-time.Hour)
+	assert.False(t, inc.active(), "past maintenance")
+}
@@ -3,9 +3,16 @@ package collectors
 import (
 	"strings"
 
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
 	"github.com/mmcdole/gofeed"
 )
 
+func init() {
+	RegisterProvider("generic", []string{"okta", "openai"}, func(maas.ServiceFeed) Scraper {
+		return genericParser{}
+	})
+}
+
 // Scraper extracts provider-specific information from a feed item and
 // also provides a deduplication key used to filter repeated entries.
 type Scraper interface {
@@ -16,6 +23,15 @@ type Scraper interface {
 	IncidentKey(item *gofeed.Item) string
 }
 
+// StatusClassifier is implemented by scrapers that classify the state of an
+// item themselves. The state is one of resolved, maintenance, service_issue or
+// outage, or empty when the item does not describe an incident. A confidence
+// of zero means the scraper cannot tell and the generic keyword detection is
+// used.
+type StatusClassifier interface {
+	ClassifyStatus(item *gofeed.Item) (state string, confidence float64)
+}
+
 type genericParser struct{}
 
 func (genericParser) ServiceInfo(item *gofeed.Item) (string, string) {
@@ -34,44 +50,24 @@ func (genericParser) IncidentKey(item *gofeed.Item) string {
 
 // ScraperForService selects a scraper based on the provider or service name.
 func ScraperForService(provider, service string) Scraper {
-	p := strings.ToLower(provider)
-	switch p {
-	case "aws":
-		return enhancedAWSParser{}
-	case "gcp":
-		return enhancedGCPParser{}
-	case "azure":
-		return enhancedAzureParser{}
-	case "genesyscloud", "genesys-cloud":
-		return genesysParser{}
-	case "avaya", "avayacloud", "avaya-cloud":
-		return enhancedAvayaParser{}
-	case "cloudflare", "cloudflare-status":
-		return enhancedCloudflareParser{}
-	case "":
-		// fall back to service name when provider not set
-	default:
-		if p != "" {
-			return genericParser{}
-		}
-	}
+	return scraperFor(maas.ServiceFeed{Name: service, Provider: provider})
+}
 
-	svc := strings.ToLower(service)
-	switch {
-	case strings.Contains(svc, "aws"):
-		return enhancedAWSParser{}
-	case strings.Contains(svc, "gcp"):
-		return enhancedGCPParser{}
-	case strings.Contains(svc, "azure"):
-		return enhancedAzureParser{}
-	case strings.Contains(svc, "genesys"):
-		return genesysParser{}
-	case strings.Contains(svc, "avaya"):
-		return enhancedAvayaParser{}
-	case strings.Contains(svc, "cloudflare"):
-		return enhancedCloudflareParser{}
-	default:
+// scraperFor returns the scraper of the configured provider. When no provider
+// is set it is inferred from the service name. Unknown providers use the
+// generic parser.
+func scraperFor(cfg maas.ServiceFeed) Scraper {
+	var (
+		p  Provider
+		ok bool
+	)
+	if cfg.Provider != "" {
+		p, ok = LookupProvider(cfg.Provider)
+	} else {
+		p, ok = providerForService(cfg.Name)
+	}
+	if !ok {
 		return genericParser{}
 	}
+	return p.Factory(cfg)
 }
-
@@ -0,0 +1,122 @@
+package collectors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	log "github.com/sirupsen/logrus"
+)
+
+// ProviderFactory creates the Scraper used for a configured service.
+type ProviderFactory func(cfg maas.ServiceFeed) Scraper
+
+// Provider describes a registered provider.
+type Provider struct {
+	Name    string
+	Aliases []string
+	Factory ProviderFactory
+}
+
+var providers = struct {
+	sync.RWMutex
+	byName map[string]*Provider
+}{byName: make(map[string]*Provider)}
+
+// RegisterProvider makes a provider available under its name and aliases.
+// Names are case insensitive. Registering a name or alias twice panics.
+func RegisterProvider(name string, aliases []string, factory func(cfg maas.ServiceFeed) Scraper) {
+	providers.Lock()
+	defer providers.Unlock()
+
+	p := &Provider{Name: strings.ToLower(name), Factory: factory}
+	for _, a := range aliases {
+		p.Aliases = append(p.Aliases, strings.ToLower(a))
+	}
+
+	for _, n := range append([]string{p.Name}, p.Aliases...) {
+		if existing, ok := providers.byName[n]; ok {
+			panic(fmt.Sprintf("collectors: provider %q already registered by %s", n, existing.Name))
+		}
+	}
+	for _, n := range append([]string{p.Name}, p.Aliases...) {
+		providers.byName[n] = p
+	}
+}
+
+// LookupProvider returns the provider registered under name or one of its
+// aliases.
+func LookupProvider(name string) (Provider, bool) {
+	providers.RLock()
+	defer providers.RUnlock()
+
+	p, ok := providers.byName[strings.ToLower(name)]
+	if !ok {
+		return Provider{}, false
+	}
+	return *p, true
+}
+
+// Providers returns every registered provider sorted by name.
+func Providers() []Provider {
+	providers.RLock()
+	defer providers.RUnlock()
+
+	seen := make(map[string]bool)
+	list := []Provider{}
+	for _, p := range providers.byName {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		list = append(list, *p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list
+}
+
+// providerForService infers the provider from a service name. The whole name
+// is tried first, then each word of it. A name whose words match several
+// providers is ambiguous and yields no provider.
+func providerForService(service string) (Provider, bool) {
+	svc := strings.ToLower(service)
+	if p, ok := LookupProvider(svc); ok {
+		return p, true
+	}
+
+	var found *Provider
+	words := strings.FieldsFunc(svc, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	for _, w := range words {
+		p, ok := LookupProvider(w)
+		if !ok || (found != nil && found.Name == p.Name) {
+			continue
+		}
+		if found != nil {
+			log.Warnf("service %q matches providers %s and %s, set provider explicitly", service, found.Name, p.Name)
+			return Provider{}, false
+		}
+		found = &p
+	}
+
+	if found == nil {
+		return Provider{}, false
+	}
+	return *found, true
+}
+
+// listProviders writes a table of registered providers and their aliases.
+func listProviders(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tALIASES")
+	for _, p := range Providers() {
+		fmt.Fprintf(tw, "%s\t%s\n", p.Name, strings.Join(p.Aliases, ", "))
+	}
+	return tw.Flush()
+}
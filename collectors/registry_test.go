@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+type internalVendorParser struct {
+	customer string
+}
+
+func (p internalVendorParser) ServiceInfo(item *gofeed.Item) (string, string) {
+	return p.customer, ""
+}
+
+func (internalVendorParser) IncidentKey(item *gofeed.Item) string {
+	return item.GUID
+}
+
+func TestRegisterProvider(t *testing.T) {
+	RegisterProvider("InternalVendor", []string{"internal-vendor"}, func(cfg maas.ServiceFeed) Scraper {
+		return internalVendorParser{customer: cfg.Customer}
+	})
+
+	p, ok := LookupProvider("internal-vendor")
+	require.True(t, ok)
+	assert.Equal(t, "internalvendor", p.Name)
+	assert.Equal(t, []string{"internal-vendor"}, p.Aliases)
+
+	scraper := scraperFor(maas.ServiceFeed{Name: "vendor", Provider: "InternalVendor", Customer: "acme"})
+	assert.Equal(t, internalVendorParser{customer: "acme"}, scraper)
+
+	assert.Panics(t, func() {
+		RegisterProvider("other", []string{"internal-vendor"}, func(maas.ServiceFeed) Scraper { return genericParser{} })
+	})
+	_, ok = LookupProvider("other")
+	assert.False(t, ok)
+}
+
+func TestScraperForServiceName(t *testing.T) {
+	tests := []struct {
+		service  string
+		expected Scraper
+	}{
+		{service: "aws", expected: enhancedAWSParser{}},
+		{service: "Vattenfall-gcp", expected: enhancedGCPParser{}},
+		{service: "genesys-cloud", expected: genesysParser{}},
+		{service: "prod-avaya-cloud", expected: enhancedAvayaParser{}},
+		{service: "aws-avaya-bridge", expected: genericParser{}},
+		{service: "status", expected: genericParser{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ScraperForService("", tt.service))
+		})
+	}
+}
+
+func TestListProviders(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, listProviders(&buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "PROVIDER")
+	assert.Regexp(t, `(?m)^avaya\s+avayacloud, avaya-cloud$`, out)
+	assert.Regexp(t, `(?m)^generic\s+okta, openai$`, out)
+	assert.Regexp(t, `(?m)^aws\s*$`, out)
+}
@@ -0,0 +1,180 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	log "github.com/sirupsen/logrus"
+)
+
+// urlForgetter is implemented by connectors keeping state per feed URL, such
+// as connectors.HTTPConnector.
+type urlForgetter interface {
+	Forget(url string)
+}
+
+// configReloader re-reads the configuration file and reconciles the feed
+// scrapers scheduled on the exporter with the services it lists. Feeds whose
+// configuration is unchanged keep running untouched.
+type configReloader struct {
+	mu            sync.Mutex
+	app           *kingpin.Application
+	path          string
+	exporter      *maas.Exporter
+	connector     maas.Connector
+	store         IncidentStore
+	config        *Config
+	services      map[string]maas.ServiceFeed
+	modTime       time.Time
+	watchInterval time.Duration
+}
+
+func newConfigReloader(app *kingpin.Application, path string, cfg *Config) (*configReloader, error) {
+	services, err := servicesByName(cfg.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &configReloader{
+		app:      app,
+		path:     path,
+		store:    NewMemoryStore(),
+		config:   cfg,
+		services: services,
+	}
+
+	if fi, err := os.Stat(path); err == nil {
+		r.modTime = fi.ModTime()
+	}
+
+	app.Flag("config.watch-interval", "Interval to check the configuration file for changes. 0 disables watching.").
+		Default("0s").DurationVar(&r.watchInterval)
+
+	return r, nil
+}
+
+// Reload implements maas.Reloader. The running configuration is kept when the
+// new file cannot be loaded.
+func (r *configReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if fi, err := os.Stat(r.path); err == nil {
+		r.modTime = fi.ModTime()
+	}
+
+	cfg, err := LoadConfig(r.path)
+	if err != nil {
+		return err
+	}
+
+	next, err := servicesByName(cfg.Services)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ListenAddress != r.config.ListenAddress || cfg.ListenPort != r.config.ListenPort || cfg.LogLevel != r.config.LogLevel {
+		log.Warn("changes to listen_address, listen_port and log_level require a restart")
+	}
+
+	var remove []string
+	for name, svc := range r.services {
+		if n, ok := next[name]; !ok || !reflect.DeepEqual(svc, n) {
+			remove = append(remove, name)
+		}
+	}
+
+	// Every scraper is built before anything is unscheduled, so that a
+	// failure leaves the running configuration in place.
+	var add []*maas.ScheduledScraper
+	for name, svc := range next {
+		if old, ok := r.services[name]; ok && reflect.DeepEqual(old, svc) {
+			continue
+		}
+		add = append(add, newServiceCollector(r.app, svc, r.store))
+	}
+
+	if err := r.exporter.ReplaceScheduledScrapers(remove, add); err != nil {
+		return err
+	}
+
+	r.forgetURLs(remove, next)
+	r.config = cfg
+	r.services = next
+
+	return nil
+}
+
+// forgetURLs lets the connector drop the state it keeps for the URLs of the
+// removed services that no remaining service uses.
+func (r *configReloader) forgetURLs(removed []string, next map[string]maas.ServiceFeed) {
+	f, ok := r.connector.(urlForgetter)
+	if !ok {
+		return
+	}
+
+	used := make(map[string]bool, len(next))
+	for _, svc := range next {
+		used[svc.URL] = true
+	}
+
+	for _, name := range removed {
+		if url := r.services[name].URL; !used[url] {
+			f.Forget(url)
+			used[url] = true
+		}
+	}
+}
+
+// Watch implements maas.Watcher. It polls the configuration file and triggers
+// a reload whenever its modification time changes, until ctx is done.
+func (r *configReloader) Watch(ctx context.Context) {
+	if r.watchInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		fi, err := os.Stat(r.path)
+		if err != nil {
+			log.Warnf("unable to stat %s: %s", r.path, err)
+			continue
+		}
+
+		r.mu.Lock()
+		changed := !fi.ModTime().Equal(r.modTime)
+		r.mu.Unlock()
+
+		if changed {
+			log.Infof("%s changed, reloading configuration", r.path)
+			_ = r.exporter.Reload()
+		}
+	}
+}
+
+func servicesByName(services []maas.ServiceFeed) (map[string]maas.ServiceFeed, error) {
+	byName := make(map[string]maas.ServiceFeed, len(services))
+
+	for _, svc := range services {
+		if _, ok := byName[svc.Name]; ok {
+			return nil, fmt.Errorf("duplicate service name %q", svc.Name)
+		}
+		byName[svc.Name] = svc
+	}
+
+	return byName, nil
+}
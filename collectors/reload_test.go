@@ -0,0 +1,163 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+type ReloadTestSuite struct {
+	suite.Suite
+	path      string
+	scheduler *maas.MockScheduler
+	reloader  *configReloader
+	exporter  *maas.Exporter
+}
+
+func (s *ReloadTestSuite) SetupTest() {
+	aws, err := os.ReadFile("testdata/aws_outage.rss")
+	s.Require().NoError(err)
+	azure, err := os.ReadFile("testdata/azure_issue.rss")
+	s.Require().NoError(err)
+
+	s.path = filepath.Join(s.T().TempDir(), "config.yml")
+	s.writeConfig(`
+services:
+  - name: aws
+    provider: aws
+    url: http://mock.aws/feed
+  - name: azure
+    provider: azure
+    url: http://mock.azure/feed
+`)
+
+	app := kingpin.New("test", "")
+	cfg, err := LoadConfig(s.path)
+	s.Require().NoError(err)
+
+	s.reloader, err = newConfigReloader(app, s.path, cfg)
+	s.Require().NoError(err)
+
+	scrapers := []*maas.ScheduledScraper{}
+	for _, svc := range cfg.Services {
+		scrapers = append(scrapers, NewFeedCollector(app, svc))
+	}
+
+	s.scheduler = maas.NewMockScheduler()
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{
+		"http://mock.aws/feed":   string(aws),
+		"http://mock.azure/feed": string(azure),
+	}}
+
+	s.exporter, err = maas.NewExporter(app, connector,
+		maas.WithScheduledScrapers(scrapers...),
+		maas.WithReloader(s.reloader),
+		maas.WithScheduler(s.scheduler),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	s.Require().NoError(err)
+	s.reloader.exporter = s.exporter
+	startExporter(s.T(), s.exporter)
+}
+
+func (s *ReloadTestSuite) writeConfig(content string) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(content), 0o600))
+}
+
+func (s *ReloadTestSuite) entryIDs() []int {
+	ids := []int{}
+	for _, e := range s.scheduler.Entries() {
+		ids = append(ids, int(e.ID))
+	}
+	return ids
+}
+
+func (s *ReloadTestSuite) TestAddsAndRemovesFeeds() {
+	s.Equal(5, testutil.CollectAndCount(s.exporter, "test_aws_service_status"))
+
+	s.writeConfig(`
+services:
+  - name: azure
+    provider: azure
+    url: http://mock.azure/feed
+  - name: gcp
+    provider: gcp
+    url: http://mock.azure/feed
+`)
+	s.NoError(s.exporter.Reload())
+
+	s.Len(s.scheduler.Entries(), 2)
+	s.Equal(0, testutil.CollectAndCount(s.exporter, "test_aws_service_status"))
+	s.Contains(s.reloader.services, "gcp")
+	s.NotContains(s.reloader.services, "aws")
+}
+
+func (s *ReloadTestSuite) TestUnchangedFeedsKeepRunning() {
+	before := s.entryIDs()
+
+	s.writeConfig(`
+services:
+  - name: aws
+    provider: aws
+    url: http://mock.aws/feed
+  - name: azure
+    provider: azure
+    url: http://mock.azure/feed
+    interval: 60
+`)
+	s.NoError(s.exporter.Reload())
+
+	after := s.entryIDs()
+	s.Contains(after, before[0])
+	s.NotContains(after, before[1])
+	s.Equal(5, testutil.CollectAndCount(s.exporter, "test_aws_service_status"))
+}
+
+func (s *ReloadTestSuite) TestForgetsURLsNoLongerScraped() {
+	connector := &forgetfulConnector{}
+	s.reloader.connector = connector
+
+	s.writeConfig(`
+services:
+  - name: azure
+    provider: azure
+    url: http://mock.azure/feed
+  - name: gcp
+    provider: gcp
+    url: http://mock.azure/feed
+`)
+	s.NoError(s.exporter.Reload())
+	s.Equal([]string{"http://mock.aws/feed"}, connector.forgotten)
+}
+
+func (s *ReloadTestSuite) TestFailedReloadKeepsConfig() {
+	before := s.entryIDs()
+
+	s.writeConfig("services:\n  - name: aws\n  - name: aws\n")
+	s.EqualError(s.exporter.Reload(), `duplicate service name "aws"`)
+
+	s.Equal(before, s.entryIDs())
+	s.Len(s.reloader.services, 2)
+}
+
+// forgetfulConnector records the URLs it is asked to forget.
+type forgetfulConnector struct {
+	connectors.MockHTTPConnector
+	forgotten []string
+}
+
+func (c *forgetfulConnector) Forget(url string) {
+	c.forgotten = append(c.forgotten, url)
+}
+
+func TestReloadTestSuite(t *testing.T) {
+	suite.Run(t, new(ReloadTestSuite))
+}
@@ -0,0 +1,252 @@
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"github.com/mmcdole/gofeed"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	RegisterProvider("rules", nil, func(cfg maas.ServiceFeed) Scraper {
+		p, err := newRulesParser(cfg.Rules)
+		if err != nil {
+			log.Errorf("%s: invalid rules, using generic parser: %s", cfg.Name, err)
+			return genericParser{}
+		}
+		return p
+	})
+}
+
+var (
+	ruleStates = []string{"resolved", "maintenance", "service_issue", "outage"}
+	ruleFields = []string{"title", "description", "content", "guid", "link"}
+)
+
+// rulesParser is a Scraper configured entirely from the rules block of a
+// service, for feeds without a dedicated parser.
+type rulesParser struct {
+	states      []stateMatcher
+	service     []fieldMatcher
+	region      []fieldMatcher
+	incidentKey *template.Template
+}
+
+type stateMatcher struct {
+	state    string
+	severity *Severity
+	regex    *regexp.Regexp
+	contains []string
+	fields   []string
+}
+
+type fieldMatcher struct {
+	field string
+	regex *regexp.Regexp
+}
+
+// incidentKeyData is the data available to the incident_key template.
+type incidentKeyData struct {
+	Title       string
+	Description string
+	Content     string
+	GUID        string
+	Link        string
+	Service     string
+	Region      string
+}
+
+func newRulesParser(rules *maas.FeedRules) (*rulesParser, error) {
+	if rules == nil {
+		return nil, errors.New("provider rules requires a rules block")
+	}
+
+	p := &rulesParser{}
+
+	for i, r := range rules.States {
+		if !contains(ruleStates, r.State) {
+			return nil, fmt.Errorf("states[%d]: unknown state %q", i, r.State)
+		}
+		if r.Regex == "" && len(r.Contains) == 0 {
+			return nil, fmt.Errorf("states[%d]: regex or contains is required", i)
+		}
+
+		m := stateMatcher{state: r.State, fields: r.Fields}
+		if len(m.fields) == 0 {
+			m.fields = []string{"title", "description", "content"}
+		}
+		for _, f := range m.fields {
+			if !contains(ruleFields, f) {
+				return nil, fmt.Errorf("states[%d]: unknown field %q", i, f)
+			}
+		}
+		if r.Regex != "" {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("states[%d]: %w", i, err)
+			}
+			m.regex = re
+		}
+		if r.Severity != "" {
+			sev, err := ParseSeverity(r.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("states[%d]: %w", i, err)
+			}
+			m.severity = &sev
+		}
+		for _, c := range r.Contains {
+			m.contains = append(m.contains, strings.ToLower(c))
+		}
+		p.states = append(p.states, m)
+	}
+
+	var err error
+	if p.service, err = compileFieldRules("service", rules.Service); err != nil {
+		return nil, err
+	}
+	if p.region, err = compileFieldRules("region", rules.Region); err != nil {
+		return nil, err
+	}
+
+	if rules.IncidentKey != "" {
+		tmpl, err := template.New("incident_key").Option("missingkey=error").Parse(rules.IncidentKey)
+		if err != nil {
+			return nil, fmt.Errorf("incident_key: %w", err)
+		}
+		p.incidentKey = tmpl
+	}
+
+	return p, nil
+}
+
+func compileFieldRules(name string, rules []maas.FieldRule) ([]fieldMatcher, error) {
+	matchers := make([]fieldMatcher, 0, len(rules))
+
+	for i, r := range rules {
+		if !contains(ruleFields, r.Field) {
+			return nil, fmt.Errorf("%s[%d]: unknown field %q", name, i, r.Field)
+		}
+		re, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+		matchers = append(matchers, fieldMatcher{field: r.Field, regex: re})
+	}
+
+	return matchers, nil
+}
+
+// ServiceInfo returns the first value extracted by the service and region
+// rules. A rule yields its first capture group, or the whole match when the
+// expression has no groups.
+func (p *rulesParser) ServiceInfo(item *gofeed.Item) (string, string) {
+	return extractField(p.service, item), extractField(p.region, item)
+}
+
+// IncidentKey renders the incident_key template, falling back to the generic
+// key when no template is configured or rendering fails.
+func (p *rulesParser) IncidentKey(item *gofeed.Item) string {
+	if p.incidentKey == nil {
+		return genericParser{}.IncidentKey(item)
+	}
+
+	svc, region := p.ServiceInfo(item)
+	data := incidentKeyData{
+		Title:       strings.TrimSpace(item.Title),
+		Description: item.Description,
+		Content:     item.Content,
+		GUID:        item.GUID,
+		Link:        item.Link,
+		Service:     svc,
+		Region:      region,
+	}
+
+	var buf bytes.Buffer
+	if err := p.incidentKey.Execute(&buf, data); err != nil {
+		log.Debugf("unable to render incident key: %s", err)
+		return genericParser{}.IncidentKey(item)
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// ClassifyStatus returns the state of the first matching state rule. Items no
+// rule matches are left to the generic keyword detection.
+func (p *rulesParser) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	if m := p.match(item); m != nil {
+		return m.state, 1
+	}
+	return "", 0
+}
+
+// ClassifySeverity returns the severity of the first matching state rule when
+// the rule sets one.
+func (p *rulesParser) ClassifySeverity(item *gofeed.Item) (Severity, bool) {
+	if m := p.match(item); m != nil && m.severity != nil {
+		return *m.severity, true
+	}
+	return 0, false
+}
+
+func (p *rulesParser) match(item *gofeed.Item) *stateMatcher {
+	for i, m := range p.states {
+		for _, f := range m.fields {
+			value := itemField(item, f)
+			if m.regex != nil && m.regex.MatchString(value) {
+				return &p.states[i]
+			}
+			lower := strings.ToLower(value)
+			for _, c := range m.contains {
+				if strings.Contains(lower, c) {
+					return &p.states[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func extractField(matchers []fieldMatcher, item *gofeed.Item) string {
+	for _, m := range matchers {
+		match := m.regex.FindStringSubmatch(itemField(item, m.field))
+		if match == nil {
+			continue
+		}
+		if len(match) > 1 {
+			return strings.TrimSpace(match[1])
+		}
+		return strings.TrimSpace(match[0])
+	}
+	return ""
+}
+
+func itemField(item *gofeed.Item, field string) string {
+	switch field {
+	case "title":
+		return item.Title
+	case "description":
+		return item.Description
+	case "content":
+		return item.Content
+	case "guid":
+		return item.GUID
+	case "link":
+		return item.Link
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
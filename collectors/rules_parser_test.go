@@ -0,0 +1,161 @@
+package collectors
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/mmcdole/gofeed"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+const oktaRulesConfig = `
+services:
+  - name: okta
+    provider: rules
+    url: http://mock.okta/feed
+    rules:
+      states:
+        - state: resolved
+          contains: ["has been resolved", "issue is resolved"]
+        - state: outage
+          regex: '(?i)service (disruption|outage)'
+          fields: [title]
+          severity: major
+      service:
+        - field: content
+          regex: '(authentication|provisioning|single sign-on)'
+      region:
+        - field: content
+          regex: '((?:US|EMEA|APAC) Cell \d+)'
+      incident_key: '{{ .Link }}'
+`
+
+func loadRulesService(t *testing.T, config string) maas.ServiceFeed {
+	cfg, err := ParseConfig([]byte(config))
+	require.NoError(t, err)
+	require.Len(t, cfg.Services, 1)
+	return cfg.Services[0]
+}
+
+func TestRulesParserItem(t *testing.T) {
+	svc := loadRulesService(t, oktaRulesConfig)
+	parser := scraperFor(svc)
+	require.IsType(t, &rulesParser{}, parser)
+
+	item := &gofeed.Item{
+		Title:   "Service Disruption",
+		Link:    "https://status.okta.com/#incident/a9CKZ000000oLkp2AE",
+		GUID:    "https://www.salesforce.com/a9CKZ000000oLkp2AE",
+		Content: "Okta Engineering is investigating reports of authentication failures affecting customers in US Cell 1, US Cell 3, and EMEA Cell 9.",
+	}
+
+	service, region := parser.ServiceInfo(item)
+	assert.Equal(t, "authentication", service)
+	assert.Equal(t, "US Cell 1", region)
+	assert.Equal(t, "https://status.okta.com/#incident/a9CKZ000000oLkp2AE", parser.IncidentKey(item))
+
+	state, active := itemStatus(parser, item)
+	assert.Equal(t, "outage", state)
+	assert.True(t, active)
+	assert.Equal(t, SeverityPartialOutage, itemSeverity(parser, item, state))
+
+	item.Title = "Authentication Issue"
+	item.Content = "The issue is resolved."
+	state, active = itemStatus(parser, item)
+	assert.Equal(t, "resolved", state)
+	assert.False(t, active)
+
+	// Items no rule matches fall back to keyword detection.
+	item.Content = "We are investigating elevated latency."
+	state, active = itemStatus(parser, item)
+	assert.Equal(t, "service_issue", state)
+	assert.True(t, active)
+}
+
+func TestRulesParserScrape(t *testing.T) {
+	data, err := os.ReadFile("testdata/okta_service_disruption.atom")
+	require.NoError(t, err)
+
+	app := kingpin.New("test", "")
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{"http://mock.okta/feed": string(data)}}
+	e, err := maas.NewExporter(app, connector,
+		maas.WithScheduledScrapers(NewFeedCollector(app, loadRulesService(t, oktaRulesConfig))),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	require.NoError(t, err)
+	startExporter(t, e)
+
+	expected := `
+# HELP test_okta_service_issue_info Details for active service issues
+# TYPE test_okta_service_issue_info gauge
+test_okta_service_issue_info{customer="",guid="https://www.salesforce.com/a9CKZ000000oLkp2AE",link="https://status.okta.com/#incident/a9CKZ000000oLkp2AE",region="US Cell 1",service="okta",service_name="authentication",title="Service Disruption"} 1
+# HELP test_okta_service_status Current service status
+# TYPE test_okta_service_status gauge
+test_okta_service_status{customer="",service="okta",state="maintenance"} 0
+test_okta_service_status{customer="",service="okta",state="ok"} 0
+test_okta_service_status{customer="",service="okta",state="outage"} 1
+test_okta_service_status{customer="",service="okta",state="service_issue"} 0
+test_okta_service_status{customer="",service="okta",state="unknown"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected), "test_okta_service_status", "test_okta_service_issue_info"))
+}
+
+func TestRulesParserConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{
+			name:  "Missing rules block",
+			rules: "",
+			err:   "provider rules requires a rules block",
+		},
+		{
+			name:  "Unknown state",
+			rules: "    rules:\n      states:\n        - state: broken\n          contains: [down]\n",
+			err:   `states[0]: unknown state "broken"`,
+		},
+		{
+			name:  "Unknown severity",
+			rules: "    rules:\n      states:\n        - state: outage\n          contains: [down]\n          severity: dire\n",
+			err:   `states[0]: unknown severity "dire"`,
+		},
+		{
+			name:  "State without matcher",
+			rules: "    rules:\n      states:\n        - state: outage\n",
+			err:   "states[0]: regex or contains is required",
+		},
+		{
+			name:  "Invalid regex",
+			rules: "    rules:\n      service:\n        - field: title\n          regex: '(['\n",
+			err:   "service[0]: error parsing regexp",
+		},
+		{
+			name:  "Unknown field",
+			rules: "    rules:\n      region:\n        - field: summary\n          regex: '.*'\n",
+			err:   `region[0]: unknown field "summary"`,
+		},
+		{
+			name:  "Invalid template",
+			rules: "    rules:\n      incident_key: '{{ .GUID '\n",
+			err:   "incident_key: template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte("services:\n  - name: vendor\n    provider: rules\n    url: https://example.com/feed\n" + tt.rules))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Severity ranks how badly a service is affected. The levels follow the
+// component statuses of Atlassian Statuspage, which many vendors publish.
+type Severity int
+
+const (
+	SeverityOperational Severity = iota
+	SeverityMaintenance
+	SeverityDegraded
+	SeverityPartialOutage
+	SeverityMajorOutage
+)
+
+// severityNames are the values of the impact label of service_impact, indexed
+// by Severity.
+var severityNames = []string{"operational", "under_maintenance", "degraded_performance", "partial_outage", "major_outage"}
+
+// statuspageImpacts maps the incident impact levels of Statuspage to
+// severities.
+var statuspageImpacts = map[string]Severity{
+	"none":        SeverityOperational,
+	"maintenance": SeverityMaintenance,
+	"minor":       SeverityDegraded,
+	"major":       SeverityPartialOutage,
+	"critical":    SeverityMajorOutage,
+}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity accepts a severity name, such as partial_outage, or a
+// Statuspage impact level, such as major.
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, n := range severityNames {
+		if n == name {
+			return Severity(i), nil
+		}
+	}
+	if s, ok := statuspageImpacts[name]; ok {
+		return s, nil
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// SeverityClassifier is implemented by scrapers that map the impact level of
+// their vendor to a Severity. ok is false when the item does not tell.
+type SeverityClassifier interface {
+	ClassifySeverity(item *gofeed.Item) (severity Severity, ok bool)
+}
+
+// itemSeverity returns the severity of an item in the given state. The scraper
+// decides when it implements SeverityClassifier and can tell, otherwise the
+// wording of the item and finally its state are used.
+func itemSeverity(scraper Scraper, item *gofeed.Item, state string) Severity {
+	switch state {
+	case "", "resolved":
+		return SeverityOperational
+	case "maintenance":
+		return SeverityMaintenance
+	}
+
+	if c, ok := scraper.(SeverityClassifier); ok {
+		if s, ok := c.ClassifySeverity(item); ok {
+			return s
+		}
+	}
+	if s, ok := textSeverity(strings.Join([]string{item.Title, item.Description, item.Content}, " ")); ok {
+		return s
+	}
+	if state == "outage" {
+		return SeverityMajorOutage
+	}
+	return SeverityDegraded
+}
+
+// textSeverity looks for impact wording in text.
+func textSeverity(text string) (Severity, bool) {
+	text = strings.ToUpper(text)
+
+	switch {
+	case strings.Contains(text, "MAJOR OUTAGE") || strings.Contains(text, "FULL OUTAGE") ||
+		strings.Contains(text, "COMPLETE OUTAGE"):
+		return SeverityMajorOutage, true
+	case strings.Contains(text, "PARTIAL OUTAGE"):
+		return SeverityPartialOutage, true
+	case strings.Contains(text, "DEGRADED") || strings.Contains(text, "ELEVATED") ||
+		strings.Contains(text, "INCREASED LATENCY") || strings.Contains(text, "DELAYS"):
+		return SeverityDegraded, true
+	}
+	return 0, false
+}
+
+// statuspageSeverity reads impact wording from the title and the latest
+// update of a Statuspage item.
+func statuspageSeverity(item *gofeed.Item) (Severity, bool) {
+	if s, ok := textSeverity(item.Title); ok {
+		return s, true
+	}
+
+	for _, text := range []string{item.Content, item.Description} {
+		loc := statuspageUpdate.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		update := text[loc[1]:]
+		if next := strings.Index(strings.ToLower(update), "<strong>"); next != -1 {
+			update = update[:next]
+		}
+		return textSeverity(update)
+	}
+
+	return 0, false
+}
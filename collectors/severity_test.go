@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverity(t *testing.T) {
+	s, err := ParseSeverity("partial_outage")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityPartialOutage, s)
+
+	s, err = ParseSeverity("Critical")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityMajorOutage, s)
+
+	_, err = ParseSeverity("catastrophic")
+	assert.Error(t, err)
+
+	assert.Equal(t, "degraded_performance", SeverityDegraded.String())
+}
+
+func TestItemSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		scraper  Scraper
+		item     *gofeed.Item
+		state    string
+		severity Severity
+	}{
+		{
+			name:     "Resolved",
+			scraper:  genericParser{},
+			item:     &gofeed.Item{Title: "Major outage"},
+			state:    "resolved",
+			severity: SeverityOperational,
+		},
+		{
+			name:     "Maintenance",
+			scraper:  genericParser{},
+			item:     &gofeed.Item{Title: "Scheduled maintenance"},
+			state:    "maintenance",
+			severity: SeverityMaintenance,
+		},
+		{
+			name:     "Partial outage wording",
+			scraper:  genericParser{},
+			item:     &gofeed.Item{Title: "Partial outage of the API"},
+			state:    "outage",
+			severity: SeverityPartialOutage,
+		},
+		{
+			name:     "Outage without impact wording",
+			scraper:  genericParser{},
+			item:     &gofeed.Item{Title: "Outage in EU"},
+			state:    "outage",
+			severity: SeverityMajorOutage,
+		},
+		{
+			name:     "Service issue without impact wording",
+			scraper:  genericParser{},
+			item:     &gofeed.Item{Title: "Investigating login failures"},
+			state:    "service_issue",
+			severity: SeverityDegraded,
+		},
+		{
+			name:     "Statuspage latest update",
+			scraper:  enhancedCloudflareParser{},
+			item:     &gofeed.Item{Title: "API errors", Content: "<p><strong>Monitoring</strong> - Only a partial outage remains.</p><p><strong>Investigating</strong> - Major outage of the API.</p>"},
+			state:    "service_issue",
+			severity: SeverityPartialOutage,
+		},
+		{
+			name:     "AWS service disruption",
+			scraper:  enhancedAWSParser{},
+			item:     &gofeed.Item{Title: "Service disruption: Increased Error Rates"},
+			state:    "outage",
+			severity: SeverityMajorOutage,
+		},
+		{
+			name:     "AWS informational message",
+			scraper:  enhancedAWSParser{},
+			item:     &gofeed.Item{Title: "Informational message: Elevated latency"},
+			state:    "service_issue",
+			severity: SeverityDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.severity, itemSeverity(tt.scraper, tt.item, tt.state))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+// Staleness policies, see maas.StalenessPolicy.
+const (
+	stalenessKeep    = "keep"
+	stalenessDrop    = "drop"
+	stalenessUnknown = "unknown"
+)
+
+var stalenessPolicies = []string{stalenessKeep, stalenessDrop, stalenessUnknown}
+
+// validateStalenessPolicy reports settings of cfg that cannot be used.
+func validateStalenessPolicy(cfg *maas.StalenessPolicy) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Policy != "" && !contains(stalenessPolicies, cfg.Policy) {
+		return fmt.Errorf("unknown policy %q", cfg.Policy)
+	}
+	if cfg.MaxFailures < 0 {
+		return fmt.Errorf("max_failures %d must not be negative", cfg.MaxFailures)
+	}
+	if cfg.MaxAge < 0 {
+		return errors.New("max_age must not be negative")
+	}
+	return nil
+}
+
+// staleness remembers the metrics of the last successful scrape of a feed
+// and how many scrapes failed since.
+type staleness struct {
+	policy      string
+	maxFailures int
+	maxAge      time.Duration
+
+	mu          sync.Mutex
+	last        []maas.Metric
+	lastSuccess time.Time
+	failures    int
+}
+
+func newStaleness(cfg *maas.StalenessPolicy) *staleness {
+	st := &staleness{policy: stalenessUnknown}
+	if cfg == nil {
+		return st
+	}
+
+	if cfg.Policy != "" {
+		st.policy = cfg.Policy
+	}
+	st.maxFailures = cfg.MaxFailures
+	st.maxAge = cfg.MaxAge
+	return st
+}
+
+// succeeded records the metrics of a successful scrape.
+func (st *staleness) succeeded(metrics []maas.Metric, now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.last = metrics
+	st.lastSuccess = now
+	st.failures = 0
+}
+
+// failed records a failed scrape. It returns the metrics of the last
+// successful scrape and when it happened, zero when there was none, and
+// whether the policy now applies.
+func (st *staleness) failed(now time.Time) (last []maas.Metric, lastSuccess time.Time, expired bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.failures++
+
+	switch {
+	case st.maxFailures == 0 && st.maxAge == 0:
+		expired = true
+	case st.maxFailures > 0 && st.failures >= st.maxFailures:
+		expired = true
+	case st.maxAge > 0 && (st.lastSuccess.IsZero() || now.Sub(st.lastSuccess) >= st.maxAge):
+		expired = true
+	}
+
+	return st.last, st.lastSuccess, expired
+}
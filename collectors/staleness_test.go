@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func dataAge(age float64) maas.Metric {
+	return maas.NewMetric("service_status_data_age_seconds", prometheus.GaugeValue, age, []string{"acme", ""})
+}
+
+func TestStalenessUnknownByDefault(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{}}
+	s := NewFeedScraper(maas.ServiceFeed{Name: "acme", URL: "http://mock.acme/feed"})
+
+	metrics, err := s.Scrape(connector)
+	assert.Error(t, err)
+	assert.Equal(t, s.statusMetrics(stateUnknown), metrics)
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(`<item><guid>outage-1</guid><title>Major outage</title></item>`)
+	_, err = s.Scrape(connector)
+	require.NoError(t, err)
+
+	// Not a feed at all.
+	connector.Responses["http://mock.acme/feed"] = `<html><body>Maintenance</body></html>`
+	metrics, err = s.Scrape(connector)
+	assert.Error(t, err)
+	require.Len(t, metrics, len(serviceStates)+1)
+	assert.Equal(t, s.statusMetrics(stateUnknown), metrics[:len(serviceStates)])
+}
+
+func TestEmptyFeedIsUnknown(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{
+		"http://mock.acme/feed": testFeed(),
+	}}
+	s := NewFeedScraper(maas.ServiceFeed{Name: "acme", URL: "http://mock.acme/feed"})
+
+	metrics, err := s.Scrape(connector)
+	require.NoError(t, err)
+	expected := []maas.Metric{maas.NewMetric("feed_items", prometheus.GaugeValue, 0, []string{"acme", ""})}
+	expected = append(expected, s.statusMetrics(stateUnknown)...)
+	assert.Equal(t, append(expected, dataAge(0)), metrics)
+}
+
+func TestStalenessKeepsLastMetrics(t *testing.T) {
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{}}
+	s := NewFeedScraper(maas.ServiceFeed{
+		Name:      "acme",
+		URL:       "http://mock.acme/feed",
+		Staleness: &maas.StalenessPolicy{Policy: "keep"},
+	})
+
+	// Nothing to keep before the first successful scrape.
+	metrics, err := s.Scrape(connector)
+	assert.Error(t, err)
+	assert.Nil(t, metrics)
+
+	connector.Responses["http://mock.acme/feed"] = testFeed(`<item><guid>outage-1</guid><title>Major outage</title></item>`)
+	ok, err := s.Scrape(connector)
+	require.NoError(t, err)
+	assert.Equal(t, dataAge(0), ok[len(ok)-1])
+
+	delete(connector.Responses, "http://mock.acme/feed")
+	metrics, err = s.Scrape(connector)
+	assert.Error(t, err)
+	require.Len(t, metrics, len(ok))
+	assert.Equal(t, ok[:len(ok)-1], metrics[:len(metrics)-1])
+}
+
+func TestStalenessDropAfterFailures(t *testing.T) {
+	s := NewFeedScraper(maas.ServiceFeed{
+		Name:      "acme",
+		URL:       "http://mock.acme/feed",
+		Staleness: &maas.StalenessPolicy{Policy: "drop", MaxFailures: 2},
+	})
+
+	start := time.Now()
+	s.stale.succeeded(s.statusMetrics("ok"), start)
+
+	assert.Equal(t, append(s.statusMetrics("ok"), dataAge(60)), s.staleMetrics(start.Add(time.Minute)))
+	assert.Equal(t, []maas.Metric{dataAge(120)}, s.staleMetrics(start.Add(2*time.Minute)))
+}
+
+func TestStalenessUnknownAfterMaxAge(t *testing.T) {
+	s := NewFeedScraper(maas.ServiceFeed{
+		Name:      "acme",
+		URL:       "http://mock.acme/feed",
+		Staleness: &maas.StalenessPolicy{Policy: "unknown", MaxAge: 10 * time.Minute},
+	})
+
+	// Without a successful scrape the data is too old from the start.
+	assert.Equal(t, s.statusMetrics(stateUnknown), s.staleMetrics(time.Now()))
+
+	start := time.Now()
+	last := []maas.Metric{dataAge(0)}
+	s.stale.succeeded(last, start)
+
+	assert.Equal(t, []maas.Metric{dataAge(0), dataAge(300)}, s.staleMetrics(start.Add(5*time.Minute)))
+	assert.Equal(t, append(s.statusMetrics(stateUnknown), dataAge(600)), s.staleMetrics(start.Add(10*time.Minute)))
+}
+
+func TestValidateStalenessPolicy(t *testing.T) {
+	assert.NoError(t, validateStalenessPolicy(nil))
+	assert.NoError(t, validateStalenessPolicy(&maas.StalenessPolicy{Policy: "unknown", MaxFailures: 3}))
+	assert.EqualError(t, validateStalenessPolicy(&maas.StalenessPolicy{Policy: "forget"}), `unknown policy "forget"`)
+	assert.EqualError(t, validateStalenessPolicy(&maas.StalenessPolicy{MaxFailures: -1}), "max_failures -1 must not be negative")
+	assert.EqualError(t, validateStalenessPolicy(&maas.StalenessPolicy{MaxAge: -time.Second}), "max_age must not be negative")
+}
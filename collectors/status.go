@@ -6,6 +6,18 @@ import (
 	"github.com/mmcdole/gofeed"
 )
 
+// itemStatus classifies an item with the scraper when it implements
+// StatusClassifier and is confident, and with extractServiceStatus otherwise.
+func itemStatus(scraper Scraper, item *gofeed.Item) (state string, active bool) {
+	if c, ok := scraper.(StatusClassifier); ok {
+		if st, confidence := c.ClassifyStatus(item); confidence > 0 {
+			return st, st != "" && st != "resolved"
+		}
+	}
+	_, state, active = extractServiceStatus(item)
+	return
+}
+
 // extractServiceStatus determines the service state from a feed item.
 func extractServiceStatus(item *gofeed.Item) (service string, state string, active bool) {
 	upper := func(s string) string {
@@ -25,6 +37,8 @@ func extractServiceStatus(item *gofeed.Item) (service string, state string, acti
 		 strings.Contains(combined, "<STRONG>RESOLVED</STRONG>") ||
 		 strings.Contains(combined, "THIS INCIDENT HAS BEEN RESOLVED"):
 		state = "resolved"
+	case isMaintenance(combined):
+		state = "maintenance"
 	case strings.Contains(combined, "OUTAGE") || strings.Contains(combined, "SERVICE_OUTAGE"):
 		state = "outage"
 	case strings.Contains(combined, "SERVICE ISSUE") || 
@@ -77,11 +77,11 @@ func TestExtractServiceStatus(t *testing.T) {
 			expectedActive: true,
 		},
 		{
-			name:           "Maintenance notification - no status",
+			name:           "Maintenance notification",
 			title:          "Scheduled maintenance window",
 			description:    "Scheduled maintenance will occur tonight",
-			expectedState:  "",
-			expectedActive: false,
+			expectedState:  "maintenance",
+			expectedActive: true,
 		},
 	}
 
@@ -103,4 +103,115 @@ func TestExtractServiceStatus(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+type fixedClassifier struct {
+	genericParser
+	state      string
+	confidence float64
+}
+
+func (c fixedClassifier) ClassifyStatus(item *gofeed.Item) (string, float64) {
+	return c.state, c.confidence
+}
+
+func TestItemStatusPrefersClassifier(t *testing.T) {
+	item := &gofeed.Item{Title: "Major outage affecting services"}
+
+	state, active := itemStatus(fixedClassifier{state: "service_issue", confidence: 1}, item)
+	assert.Equal(t, "service_issue", state)
+	assert.True(t, active)
+
+	state, active = itemStatus(fixedClassifier{state: "", confidence: 1}, item)
+	assert.Equal(t, "", state)
+	assert.False(t, active)
+
+	// Without confidence the generic keyword detection decides.
+	state, active = itemStatus(fixedClassifier{state: "service_issue", confidence: 0}, item)
+	assert.Equal(t, "outage", state)
+	assert.True(t, active)
+}
+
+func TestProviderClassifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		scraper    Scraper
+		item       *gofeed.Item
+		state      string
+		confidence float64
+	}{
+		{
+			name:       "Statuspage maintenance mentioning monitoring",
+			scraper:    enhancedCloudflareParser{},
+			item:       &gofeed.Item{Title: "LAX (Los Angeles) on 2025-07-03", Content: "<p><strong>Scheduled</strong> - We will be monitoring traffic during maintenance.</p>"},
+			state:      "maintenance",
+			confidence: 1,
+		},
+		{
+			name:       "Statuspage completed maintenance",
+			scraper:    enhancedCloudflareParser{},
+			item:       &gofeed.Item{Title: "LAX (Los Angeles) on 2025-07-03", Content: "<p><strong>Completed</strong> - The scheduled maintenance has been completed.</p>"},
+			state:      "resolved",
+			confidence: 1,
+		},
+		{
+			name:       "Statuspage latest update wins",
+			scraper:    enhancedCloudflareParser{},
+			item:       &gofeed.Item{Title: "DNS Service Outage - Global", Content: "<p><strong>Monitoring</strong> - A fix is in place.</p><p><strong>Investigating</strong> - Outage.</p>"},
+			state:      "outage",
+			confidence: 1,
+		},
+		{
+			name:       "Statuspage resolved",
+			scraper:    enhancedAvayaParser{},
+			item:       &gofeed.Item{Title: "AXP latency", Description: "<strong>Resolved</strong> - Latency is back to normal."},
+			state:      "resolved",
+			confidence: 1,
+		},
+		{
+			name:       "Genesys strong tags",
+			scraper:    genesysParser{},
+			item:       &gofeed.Item{Title: "WhatsApp Message Errors", Content: "<p><strong>Identified</strong> - Errors sending messages.</p>"},
+			state:      "service_issue",
+			confidence: 1,
+		},
+		{
+			name:       "Genesys text fallback",
+			scraper:    genesysParser{},
+			item:       &gofeed.Item{Title: "Degraded analytics", Description: "Reporting is degraded."},
+			state:      "service_issue",
+			confidence: 0.5,
+		},
+		{
+			name:       "AWS informational message",
+			scraper:    enhancedAWSParser{},
+			item:       &gofeed.Item{Title: "Informational message: Elevated latency", GUID: "https://status.aws.amazon.com/#s3-us-east-1_1749832722"},
+			state:      "service_issue",
+			confidence: 1,
+		},
+		{
+			name:       "AWS resolved prefix",
+			scraper:    enhancedAWSParser{},
+			item:       &gofeed.Item{Title: "[RESOLVED] Increased API error rates"},
+			state:      "resolved",
+			confidence: 1,
+		},
+		{
+			name:       "AWS unknown title",
+			scraper:    enhancedAWSParser{},
+			item:       &gofeed.Item{Title: "Amazon EC2 update"},
+			state:      "",
+			confidence: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := tt.scraper.(StatusClassifier)
+			assert.True(t, ok)
+
+			state, confidence := c.ClassifyStatus(tt.item)
+			assert.Equal(t, tt.state, state)
+			assert.Equal(t, tt.confidence, confidence)
+		})
+	}
+}
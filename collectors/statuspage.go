@@ -0,0 +1,40 @@
+package collectors
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// statuspageUpdate matches the bold status that prefixes every update in
+// feeds published by Atlassian Statuspage. Updates are listed newest first.
+var statuspageUpdate = regexp.MustCompile(`(?i)<strong>\s*(investigating|identified|update|monitoring|resolved|postmortem|scheduled|in progress|verifying|completed)\s*</strong>`)
+
+// statuspageStatus classifies an item from the status of its latest
+// Statuspage update. ok is false when the item carries no such update.
+// Scheduled and in progress maintenance has the maintenance state, and
+// completed maintenance counts as resolved.
+func statuspageStatus(item *gofeed.Item) (state string, ok bool) {
+	var match []string
+	for _, text := range []string{item.Content, item.Description} {
+		if match = statuspageUpdate.FindStringSubmatch(text); match != nil {
+			break
+		}
+	}
+	if match == nil {
+		return "", false
+	}
+
+	switch strings.ToLower(match[1]) {
+	case "resolved", "postmortem", "completed":
+		return "resolved", true
+	case "scheduled", "in progress", "verifying":
+		return "maintenance", true
+	}
+
+	if strings.Contains(strings.ToUpper(item.Title), "OUTAGE") {
+		return "outage", true
+	}
+	return "service_issue", true
+}
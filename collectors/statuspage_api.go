@@ -0,0 +1,110 @@
+package collectors
+
+import (
+	"strings"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+)
+
+// serviceTypeStatuspage selects the Statuspage API instead of the RSS or Atom
+// feed of a service.
+const serviceTypeStatuspage = "statuspage"
+
+// serviceTypes are the accepted values of the type field of a service. An
+// empty type is a feed.
+var serviceTypes = []string{"feed", serviceTypeStatuspage}
+
+// statuspageIncidents converts the unresolved incidents and the scheduled
+// maintenance of a Statuspage summary into incidents.
+func statuspageIncidents(summary *connectors.StatuspageSummary) []*incident {
+	incidents := make([]*incident, 0, len(summary.Incidents)+len(summary.ScheduledMaintenances))
+
+	for _, si := range summary.Incidents {
+		incidents = append(incidents, statuspageIncident(si, false))
+	}
+	for _, si := range summary.ScheduledMaintenances {
+		incidents = append(incidents, statuspageIncident(si, true))
+	}
+
+	return incidents
+}
+
+func statuspageIncident(si connectors.StatuspageIncident, maintenance bool) *incident {
+	inc := &incident{
+		key:     si.ID,
+		title:   strings.TrimSpace(si.Name),
+		link:    si.Shortlink,
+		guid:    si.ID,
+		time:    si.UpdatedAt,
+		start:   si.CreatedAt,
+		updated: si.UpdatedAt,
+	}
+	if si.StartedAt != nil {
+		inc.start = *si.StartedAt
+	}
+
+	names := make([]string, 0, len(si.Components))
+	worst := SeverityOperational
+	for _, c := range si.Components {
+		names = append(names, c.Name)
+		inc.components = append(inc.components, Component{Name: c.Name})
+		if s, err := ParseSeverity(c.Status); err == nil && s > worst {
+			worst = s
+		}
+	}
+	inc.serviceName = strings.Join(names, ", ")
+
+	switch {
+	case si.Status == "resolved" || si.Status == "postmortem" || si.Status == "completed":
+		inc.state = "resolved"
+		inc.severity = SeverityOperational
+	case maintenance:
+		inc.state = "maintenance"
+		inc.severity = SeverityMaintenance
+		inc.ongoing = si.Status == "in_progress" || si.Status == "verifying"
+		if si.ScheduledFor != nil && si.ScheduledUntil != nil {
+			inc.windowStart, inc.windowEnd = *si.ScheduledFor, *si.ScheduledUntil
+		}
+	default:
+		inc.state = "service_issue"
+		if si.Impact == "critical" {
+			inc.state = "outage"
+		}
+		inc.severity = SeverityDegraded
+		if s, ok := statuspageImpacts[si.Impact]; ok && s > SeverityMaintenance {
+			inc.severity = s
+		} else if worst > SeverityMaintenance {
+			inc.severity = worst
+		}
+	}
+
+	return inc
+}
+
+// componentMetrics reports the status of every component of a Statuspage
+// page. Component groups only label their members.
+func (s *FeedScraper) componentMetrics(components []connectors.StatuspageComponent) []maas.Metric {
+	metrics := []maas.Metric{}
+
+	groups := make(map[string]string)
+	for _, c := range components {
+		if c.Group {
+			groups[c.ID] = c.Name
+		}
+	}
+
+	for _, c := range components {
+		if c.Group {
+			continue
+		}
+		severity, err := ParseSeverity(c.Status)
+		if err != nil {
+			continue
+		}
+		metrics = append(metrics, s.impactMetrics("service_component_status", severity, s.Config.Name, s.Config.Customer, c.Name, groups[c.GroupID])...)
+	}
+
+	return metrics
+}
@@ -0,0 +1,120 @@
+package collectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestStatuspageService(t *testing.T) {
+	summary, err := os.ReadFile("testdata/statuspage_summary.json")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/summary.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(summary)
+	}))
+	defer srv.Close()
+
+	app := kingpin.New("test", "")
+	cfg := maas.ServiceFeed{Name: "cloudflare", Type: "statuspage", URL: srv.URL, Interval: 300}
+	e, err := maas.NewExporter(app, connectors.NewHTTPConnector(),
+		maas.WithScheduledScrapers(NewFeedCollector(app, cfg)),
+		maas.WithLabels(&maas.MockLabels{}),
+		maas.WithArgs([]string{"--web.listen-port=0", "--no-scrape.startup-jitter"}),
+	)
+	require.NoError(t, err)
+	startExporter(t, e)
+
+	expected := `
+# HELP test_cloudflare_service_issue_info Details for active service issues
+# TYPE test_cloudflare_service_issue_info gauge
+test_cloudflare_service_issue_info{customer="",guid="inc1",link="https://stspg.io/inc1",region="",service="cloudflare",service_name="API",title="Cloudflare API unavailable"} 1
+test_cloudflare_service_issue_info{customer="",guid="inc2",link="https://stspg.io/inc2",region="",service="cloudflare",service_name="Cloudflare Workers",title="Increased Workers latency"} 1
+# HELP test_cloudflare_service_severity Severity of the most severe active incident, from 0 (operational) to 4 (major outage)
+# TYPE test_cloudflare_service_severity gauge
+test_cloudflare_service_severity{customer="",service="cloudflare"} 4
+# HELP test_cloudflare_service_status Current service status
+# TYPE test_cloudflare_service_status gauge
+test_cloudflare_service_status{customer="",service="cloudflare",state="maintenance"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="ok"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="outage"} 1
+test_cloudflare_service_status{customer="",service="cloudflare",state="service_issue"} 0
+test_cloudflare_service_status{customer="",service="cloudflare",state="unknown"} 0
+# HELP test_cloudflare_service_maintenance_window_start_timestamp_seconds Scheduled start of announced maintenance
+# TYPE test_cloudflare_service_maintenance_window_start_timestamp_seconds gauge
+test_cloudflare_service_maintenance_window_start_timestamp_seconds{customer="",incident="mnt1",service="cloudflare",title="LAX (Los Angeles) on 2025-06-12"} 1.7497656e+09
+test_cloudflare_service_maintenance_window_start_timestamp_seconds{customer="",incident="mnt2",service="cloudflare",title="FRA (Frankfurt) on 2099-01-10"} 4.0716936e+09
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(expected),
+		"test_cloudflare_service_issue_info",
+		"test_cloudflare_service_severity",
+		"test_cloudflare_service_status",
+		"test_cloudflare_service_maintenance_window_start_timestamp_seconds",
+	))
+
+	// Four components in every state, the group itself is not reported.
+	assert.Equal(t, 4*len(severityNames), testutil.CollectAndCount(e, "test_cloudflare_service_component_status"))
+	components := `
+# HELP test_cloudflare_service_component_status Current status of a component of the service
+# TYPE test_cloudflare_service_component_status gauge
+test_cloudflare_service_component_status{component="API",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="degraded_performance"} 0
+test_cloudflare_service_component_status{component="API",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="major_outage"} 1
+test_cloudflare_service_component_status{component="API",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="operational"} 0
+test_cloudflare_service_component_status{component="API",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="partial_outage"} 0
+test_cloudflare_service_component_status{component="API",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="under_maintenance"} 0
+test_cloudflare_service_component_status{component="Cloudflare Workers",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="degraded_performance"} 1
+test_cloudflare_service_component_status{component="Cloudflare Workers",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="major_outage"} 0
+test_cloudflare_service_component_status{component="Cloudflare Workers",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="operational"} 0
+test_cloudflare_service_component_status{component="Cloudflare Workers",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="partial_outage"} 0
+test_cloudflare_service_component_status{component="Cloudflare Workers",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="under_maintenance"} 0
+test_cloudflare_service_component_status{component="Dashboard",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="degraded_performance"} 0
+test_cloudflare_service_component_status{component="Dashboard",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="major_outage"} 0
+test_cloudflare_service_component_status{component="Dashboard",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="operational"} 1
+test_cloudflare_service_component_status{component="Dashboard",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="partial_outage"} 0
+test_cloudflare_service_component_status{component="Dashboard",customer="",group="Cloudflare Sites and Services",service="cloudflare",state="under_maintenance"} 0
+test_cloudflare_service_component_status{component="Los Angeles, CA, United States - (LAX)",customer="",group="",service="cloudflare",state="degraded_performance"} 0
+test_cloudflare_service_component_status{component="Los Angeles, CA, United States - (LAX)",customer="",group="",service="cloudflare",state="major_outage"} 0
+test_cloudflare_service_component_status{component="Los Angeles, CA, United States - (LAX)",customer="",group="",service="cloudflare",state="operational"} 0
+test_cloudflare_service_component_status{component="Los Angeles, CA, United States - (LAX)",customer="",group="",service="cloudflare",state="partial_outage"} 0
+test_cloudflare_service_component_status{component="Los Angeles, CA, United States - (LAX)",customer="",group="",service="cloudflare",state="under_maintenance"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(e, strings.NewReader(components), "test_cloudflare_service_component_status"))
+}
+
+func TestStatuspageIncidentStates(t *testing.T) {
+	summary := &connectors.StatuspageSummary{
+		Incidents: []connectors.StatuspageIncident{
+			{ID: "a", Status: "investigating", Impact: "major"},
+			{ID: "b", Status: "postmortem", Impact: "critical"},
+			{ID: "c", Status: "identified", Impact: "none", Components: []connectors.StatuspageComponent{{Name: "API", Status: "partial_outage"}}},
+		},
+		ScheduledMaintenances: []connectors.StatuspageIncident{
+			{ID: "d", Status: "verifying", Impact: "maintenance"},
+		},
+	}
+
+	incidents := statuspageIncidents(summary)
+	require.Len(t, incidents, 4)
+
+	assert.Equal(t, "service_issue", incidents[0].state)
+	assert.Equal(t, SeverityPartialOutage, incidents[0].severity)
+	assert.Equal(t, "resolved", incidents[1].state)
+	assert.False(t, incidents[1].active())
+	assert.Equal(t, SeverityPartialOutage, incidents[2].severity, "severity from affected components")
+	assert.Equal(t, "maintenance", incidents[3].state)
+	assert.True(t, incidents[3].active())
+}
@@ -0,0 +1,200 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// incidentRetention is how long an incident that no longer appears in its
+// feed is remembered.
+const incidentRetention = 30 * 24 * time.Hour
+
+// IncidentStore persists the incidents known for each service, so incident
+// counters and start times survive restarts and feed truncation.
+type IncidentStore interface {
+	// Load returns the state saved for service, or an empty state when
+	// nothing was saved yet.
+	Load(service string) (*ServiceIncidents, error)
+	// Save replaces the state saved for service.
+	Save(service string, state *ServiceIncidents) error
+}
+
+// ServiceIncidents is the stored state of a service.
+type ServiceIncidents struct {
+	// Incidents holds the known incidents by incident key.
+	Incidents map[string]*IncidentRecord `json:"incidents"`
+	// Counts holds the number of new incidents observed by state.
+	Counts map[string]float64 `json:"counts"`
+}
+
+// IncidentRecord is what is known about a single incident.
+type IncidentRecord struct {
+	Key        string     `json:"key"`
+	FirstSeen  time.Time  `json:"first_seen"`
+	LastSeen   time.Time  `json:"last_seen"`
+	Started    time.Time  `json:"started"`
+	Updated    time.Time  `json:"updated"`
+	State      string     `json:"state"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+func newServiceIncidents() *ServiceIncidents {
+	return &ServiceIncidents{
+		Incidents: make(map[string]*IncidentRecord),
+		Counts:    make(map[string]float64),
+	}
+}
+
+func decodeServiceIncidents(data []byte) (*ServiceIncidents, error) {
+	state := newServiceIncidents()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Incidents == nil {
+		state.Incidents = make(map[string]*IncidentRecord)
+	}
+	if state.Counts == nil {
+		state.Counts = make(map[string]float64)
+	}
+	return state, nil
+}
+
+// NewMemoryStore returns an IncidentStore that keeps state for the lifetime of
+// the process only.
+func NewMemoryStore() IncidentStore {
+	return &memoryStore{services: make(map[string][]byte)}
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	services map[string][]byte
+}
+
+func (m *memoryStore) Load(service string) (*ServiceIncidents, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.services[service]
+	if !ok {
+		return newServiceIncidents(), nil
+	}
+	return decodeServiceIncidents(data)
+}
+
+func (m *memoryStore) Save(service string, state *ServiceIncidents) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.services[service] = data
+	return nil
+}
+
+// NewFileStore returns an IncidentStore that keeps one JSON file per service
+// in dir, creating dir when needed.
+func NewFileStore(dir string) (IncidentStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+type fileStore struct {
+	dir string
+}
+
+// name returns the file name of service without its extension. Service names
+// are escaped so that one holding a path separator stays within dir.
+func (f *fileStore) name(service string) string {
+	return url.PathEscape(service)
+}
+
+func (f *fileStore) path(service string) string {
+	return filepath.Join(f.dir, f.name(service)+".json")
+}
+
+func (f *fileStore) Load(service string) (*ServiceIncidents, error) {
+	data, err := os.ReadFile(f.path(service))
+	if os.IsNotExist(err) {
+		return newServiceIncidents(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := decodeServiceIncidents(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path(service), err)
+	}
+	return state, nil
+}
+
+// Save writes the state to a temporary file first and renames it, so a crash
+// never leaves a partially written file behind.
+func (f *fileStore) Save(service string, state *ServiceIncidents) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.dir, f.name(service)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path(service))
+}
+
+// lazyStore opens the store configured by --storage.path the first time it is
+// used, after the command line has been parsed.
+type lazyStore struct {
+	path  *string
+	once  sync.Once
+	store IncidentStore
+	err   error
+}
+
+func (l *lazyStore) open() (IncidentStore, error) {
+	l.once.Do(func() {
+		if *l.path == "" {
+			l.store = NewMemoryStore()
+			return
+		}
+		l.store, l.err = NewFileStore(*l.path)
+	})
+	return l.store, l.err
+}
+
+func (l *lazyStore) Load(service string) (*ServiceIncidents, error) {
+	store, err := l.open()
+	if err != nil {
+		return nil, err
+	}
+	return store.Load(service)
+}
+
+func (l *lazyStore) Save(service string, state *ServiceIncidents) error {
+	store, err := l.open()
+	if err != nil {
+		return err
+	}
+	return store.Save(service, state)
+}
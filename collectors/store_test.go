@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbrown007/monitoring-rss-exporter/connectors"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "state")
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	state, err := store.Load("aws")
+	require.NoError(t, err)
+	assert.Empty(t, state.Incidents)
+
+	seen := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	state.Incidents["ec2-us-east-1"] = &IncidentRecord{Key: "ec2-us-east-1", FirstSeen: seen, LastSeen: seen, State: "outage"}
+	state.Counts["outage"] = 3
+	require.NoError(t, store.Save("aws", state))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	loaded, err := store.Load("aws")
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, loaded.Counts["outage"])
+	assert.Equal(t, "outage", loaded.Incidents["ec2-us-east-1"].State)
+	assert.True(t, seen.Equal(loaded.Incidents["ec2-us-east-1"].FirstSeen))
+}
+
+func TestFileStoreEscapesServiceNames(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "state")
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	state := newServiceIncidents()
+	state.Counts["outage"] = 1
+	require.NoError(t, store.Save("../escape", state))
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.FileExists(t, filepath.Join(dir, "..%2Fescape.json"))
+
+	loaded, err := store.Load("../escape")
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+}
+
+func TestFileStoreInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "aws.json"), []byte("{"), 0o644))
+
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	_, err = store.Load("aws")
+	assert.ErrorContains(t, err, "aws.json")
+}
+
+func TestIncidentStateSurvivesRestart(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	connector := &connectors.MockHTTPConnector{Responses: map[string]string{
+		"http://mock.acme/feed": testFeed(`<item><guid>outage-1</guid><title>Major outage</title></item>`),
+	}}
+	cfg := maas.ServiceFeed{Name: "acme", URL: "http://mock.acme/feed"}
+
+	_, err = NewFeedScraper(cfg, WithIncidentStore(store)).Scrape(connector)
+	require.NoError(t, err)
+
+	restarted := NewFeedScraper(cfg, WithIncidentStore(store))
+	_, err = restarted.Scrape(connector)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, restarted.state.Counts["outage"])
+}
+
+func TestLazyStoreUsesPath(t *testing.T) {
+	path := ""
+	store, err := (&lazyStore{path: &path}).open()
+	require.NoError(t, err)
+	assert.IsType(t, &memoryStore{}, store)
+
+	path = filepath.Join(t.TempDir(), "state")
+	store, err = (&lazyStore{path: &path}).open()
+	require.NoError(t, err)
+	assert.IsType(t, &fileStore{}, store)
+	assert.DirExists(t, path)
+}
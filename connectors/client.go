@@ -0,0 +1,209 @@
+package connectors
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// NewHTTPClient returns a client configured by cfg. A nil cfg returns a client
+// with the default timeout that honours the proxy environment variables.
+// Certificate files are loaded immediately, so a missing file is reported
+// here rather than on the first request.
+func NewHTTPClient(cfg *maas.HTTPClientConfig) (*http.Client, error) {
+	if cfg == nil {
+		cfg = &maas.HTTPClientConfig{}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxy := httpproxy.FromEnvironment()
+	if cfg.ProxyURL != "" {
+		u, err := url.Parse(cfg.ProxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("proxy_url: invalid url %q", cfg.ProxyURL)
+		}
+		proxy.HTTPProxy, proxy.HTTPSProxy = cfg.ProxyURL, cfg.ProxyURL
+	}
+	if cfg.NoProxy != "" {
+		proxy.NoProxy = cfg.NoProxy
+	}
+	proxyFunc := proxy.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.BasicAuth != nil && cfg.BearerTokenFile != "" {
+		return nil, errors.New("basic_auth and bearer_token_file are mutually exclusive")
+	}
+	if cfg.BasicAuth != nil && cfg.BasicAuth.Username == "" {
+		return nil, errors.New("basic_auth: username is required")
+	}
+
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &requestRoundTripper{config: cfg, next: transport},
+	}, nil
+}
+
+// defaultHTTPClient returns the client used when a query has no client
+// configuration.
+func defaultHTTPClient() *http.Client {
+	client, err := NewHTTPClient(nil)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func newTLSConfig(cfg *maas.HTTPClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("ca_file: no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("cert_file and key_file must be set together")
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cert_file: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// requestRoundTripper adds the headers, User-Agent and credentials of its
+// configuration to every request.
+type requestRoundTripper struct {
+	config *maas.HTTPClientConfig
+	next   http.RoundTripper
+}
+
+func (rt *requestRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	userAgent := defaultUserAgent
+	if rt.config.UserAgent != "" {
+		userAgent = rt.config.UserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	// A User-Agent among the headers takes precedence.
+	for name, value := range rt.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch {
+	case rt.config.BasicAuth != nil:
+		password, err := readSecret(rt.config.BasicAuth.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("basic_auth: %w", err)
+		}
+		req.SetBasicAuth(rt.config.BasicAuth.Username, password)
+	case rt.config.BearerTokenFile != "":
+		token, err := readSecret(rt.config.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("bearer_token_file: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return rt.next.RoundTrip(req)
+}
+
+// readSecret returns the content of path without surrounding whitespace. An
+// empty path is an empty secret.
+func readSecret(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// clientCache builds one client per distinct configuration and reuses it, so
+// connections are kept alive across scrapes.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[string]*http.Client
+}
+
+// get returns the client for cfg, or def when cfg is nil.
+func (c *clientCache) get(def *http.Client, cfg *maas.HTTPClientConfig) (*http.Client, error) {
+	if cfg == nil {
+		return def, nil
+	}
+
+	key, err := clientConfigKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = make(map[string]*http.Client)
+	}
+	c.clients[key] = client
+	return client, nil
+}
+
+// clientConfigKey identifies cfg, so that equal configurations share a client
+// and cached responses. It is empty for a nil cfg.
+func clientConfigKey(cfg *maas.HTTPClientConfig) (string, error) {
+	if cfg == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
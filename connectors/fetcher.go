@@ -14,6 +14,9 @@ const (
 )
 
 // FetchFeedWithRetry retrieves the feed URL with exponential backoff retries.
+//
+// Deprecated: HTTPConnector caches feeds and revalidates them with conditional
+// requests; use it instead.
 func FetchFeedWithRetry(url string, logger *logrus.Entry) (*gofeed.Feed, error) {
 	backoff := time.Second
 	var lastErr error
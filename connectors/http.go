@@ -1,25 +1,133 @@
 package connectors
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/alecthomas/kingpin/v2"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"github.com/mmcdole/gofeed"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 )
 
-// HTTPConnector implements maas.Connector for fetching RSS feeds.
+const defaultUserAgent = "rss_exporter"
+
+// HTTPConnector implements maas.Connector for fetching RSS feeds. Statuspage
+// queries are passed on to Statuspage.
+//
+// Feeds are requested with If-None-Match and If-Modified-Since once a response
+// carried an ETag or Last-Modified header, and the previously parsed feed is
+// returned when the server answers 304 Not Modified. Responses are cached per
+// URL and HTTP client configuration, so services requesting the same URL with
+// different credentials or headers never share them. The connector is a
+// prometheus.Collector reporting requests, downloaded bytes, cache hits, the
+// latest response and how long fetching and parsing took per URL.
+//
+// Failed requests are retried following the RetryPolicy of the query, within
+// the deadline of the context given to ExecuteContext.
+//
+// Client serves queries without an HTTP client configuration. A client is
+// built for every other configuration and kept for later queries.
 type HTTPConnector struct {
-	Logger *logrus.Entry
+	Logger     *logrus.Entry
+	Client     *http.Client
+	Statuspage *StatuspageConnector
+
+	clients clientCache
+	mu      sync.Mutex
+	cache   map[cacheKey]*cachedFeed
+
+	requests   *prometheus.CounterVec
+	bytes      *prometheus.CounterVec
+	cacheHits  *prometheus.CounterVec
+	lastStatus *prometheus.GaugeVec
+	attempts   *prometheus.CounterVec
+
+	lastBytes     *prometheus.GaugeVec
+	contentType   *prometheus.GaugeVec
+	fetchDuration *prometheus.HistogramVec
+	parseDuration *prometheus.HistogramVec
+}
+
+// cacheKey identifies a URL requested with a given HTTP client configuration.
+type cacheKey struct {
+	url    string
+	client string
+}
+
+// cachedFeed is the last feed parsed from a URL with the validators needed to
+// revalidate it.
+type cachedFeed struct {
+	etag         string
+	lastModified string
+	feed         *gofeed.Feed
 }
 
 // NewHTTPConnector creates a new HTTP connector.
 func NewHTTPConnector() *HTTPConnector {
-	return &HTTPConnector{
-		Logger: logrus.WithField("component", "http_connector"),
+	c := &HTTPConnector{
+		Logger:     logrus.WithField("component", "http_connector"),
+		Client:     defaultHTTPClient(),
+		Statuspage: NewStatuspageConnector(),
+		cache:      make(map[cacheKey]*cachedFeed),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rss_exporter_http_requests_total",
+			Help: "HTTP requests made for a feed, by response status code",
+		}, []string{"url", "code"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rss_exporter_http_response_bytes_total",
+			Help: "Bytes of response bodies downloaded for a feed",
+		}, []string{"url"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rss_exporter_http_cache_hits_total",
+			Help: "Requests for a feed answered with 304 Not Modified and served from the cache",
+		}, []string{"url"}),
+		lastStatus: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rss_exporter_http_last_status_code",
+			Help: "Status code of the latest response for a feed",
+		}, []string{"url"}),
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rss_exporter_http_attempts_total",
+			Help: "Request attempts for a feed or Statuspage page, by outcome",
+		}, []string{"url", "outcome"}),
+		lastBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rss_exporter_http_last_response_bytes",
+			Help: "Size of the body of the latest response for a feed",
+		}, []string{"url"}),
+		contentType: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "rss_exporter_http_content_type_info",
+			Help: "Media type of the latest response for a feed, value is always 1",
+		}, []string{"url", "content_type"}),
+		fetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rss_exporter_http_fetch_duration_seconds",
+			Help:    "Time taken to request a feed and download its body",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"url"}),
+		parseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rss_exporter_feed_parse_duration_seconds",
+			Help:    "Time taken to parse a downloaded feed",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"url"}),
 	}
+	c.Statuspage.attempts = c.attempts
+
+	return c
 }
 
-// The query will be the URL string.
+// HTTPQuery requests the feed at URL, with the client configured by
+// HTTPClient and the retry policy Retry when set.
 type HTTPQuery struct {
-	URL string
+	URL        string
+	HTTPClient *maas.HTTPClientConfig
+	Retry      *maas.RetryPolicy
 }
 
 // Connect implements the maas.Connector interface (no-op for HTTP)
@@ -32,9 +140,181 @@ func (c *HTTPConnector) Flags(a *kingpin.Application) {
 	// No flags needed for HTTP connector
 }
 
-// Execute fetches the RSS feed.
+// Describe implements prometheus.Collector.
+func (c *HTTPConnector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.bytes.Describe(ch)
+	c.cacheHits.Describe(ch)
+	c.lastStatus.Describe(ch)
+	c.attempts.Describe(ch)
+	c.lastBytes.Describe(ch)
+	c.contentType.Describe(ch)
+	c.fetchDuration.Describe(ch)
+	c.parseDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *HTTPConnector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.bytes.Collect(ch)
+	c.cacheHits.Collect(ch)
+	c.lastStatus.Collect(ch)
+	c.attempts.Collect(ch)
+	c.lastBytes.Collect(ch)
+	c.contentType.Collect(ch)
+	c.fetchDuration.Collect(ch)
+	c.parseDuration.Collect(ch)
+}
+
+// Execute fetches the RSS feed, or the Statuspage summary for a
+// StatuspageQuery.
 func (c *HTTPConnector) Execute(query interface{}) (interface{}, error) {
-	httpQuery := query.(HTTPQuery)
-	// Reuse existing FetchFeedWithRetry logic
-	return FetchFeedWithRetry(httpQuery.URL, c.Logger)
+	return c.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext is Execute bounded by ctx. Requests in flight are aborted and
+// no retry is started that could not complete before the deadline of ctx.
+func (c *HTTPConnector) ExecuteContext(ctx context.Context, query interface{}) (interface{}, error) {
+	if q, ok := query.(StatuspageQuery); ok {
+		return c.Statuspage.ExecuteContext(ctx, q)
+	}
+
+	httpQuery, ok := query.(HTTPQuery)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query %T", query)
+	}
+
+	client, err := c.clients.get(c.Client, httpQuery.HTTPClient)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", httpQuery.URL, err)
+	}
+	clientKey, err := clientConfigKey(httpQuery.HTTPClient)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", httpQuery.URL, err)
+	}
+	key := cacheKey{url: httpQuery.URL, client: clientKey}
+
+	var feed *gofeed.Feed
+	observe := func(outcome string) {
+		c.attempts.WithLabelValues(httpQuery.URL, outcome).Inc()
+	}
+	err = newRetryPolicy(httpQuery.Retry).do(ctx, c.Logger, observe, func(ctx context.Context) error {
+		var err error
+		feed, err = c.fetch(ctx, client, key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// Forget drops the cached feed and the metrics of url, and those of the
+// Statuspage summary of url, once no service is scraping it any more.
+func (c *HTTPConnector) Forget(url string) {
+	c.mu.Lock()
+	for key := range c.cache {
+		if key.url == url {
+			delete(c.cache, key)
+		}
+	}
+	c.mu.Unlock()
+
+	labels := prometheus.Labels{"url": url}
+	c.requests.DeletePartialMatch(labels)
+	c.bytes.DeletePartialMatch(labels)
+	c.cacheHits.DeletePartialMatch(labels)
+	c.lastStatus.DeletePartialMatch(labels)
+	c.attempts.DeletePartialMatch(labels)
+	c.lastBytes.DeletePartialMatch(labels)
+	c.contentType.DeletePartialMatch(labels)
+	c.fetchDuration.DeletePartialMatch(labels)
+	c.parseDuration.DeletePartialMatch(labels)
+
+	c.Statuspage.Forget(url)
+}
+
+// fetch requests the URL of key once, revalidating the feed cached for key
+// when there is one.
+func (c *HTTPConnector) fetch(ctx context.Context, client *http.Client, key cacheKey) (*gofeed.Feed, error) {
+	url := key.url
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	cached := c.cache[key]
+	c.mu.Unlock()
+
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &transientError{err: err}
+	}
+	defer resp.Body.Close()
+
+	c.requests.WithLabelValues(url, strconv.Itoa(resp.StatusCode)).Inc()
+	c.lastStatus.WithLabelValues(url).Set(float64(resp.StatusCode))
+	c.contentType.DeletePartialMatch(prometheus.Labels{"url": url})
+	c.contentType.WithLabelValues(url, mediaType(resp.Header.Get("Content-Type"))).Set(1)
+
+	body, err := io.ReadAll(resp.Body)
+	c.fetchDuration.WithLabelValues(url).Observe(time.Since(start).Seconds())
+	c.bytes.WithLabelValues(url).Add(float64(len(body)))
+	c.lastBytes.WithLabelValues(url).Set(float64(len(body)))
+	if err != nil {
+		return nil, &transientError{err: fmt.Errorf("%s: %w", url, err)}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		c.cacheHits.WithLabelValues(url).Inc()
+		c.Logger.Debugf("%s: not modified, using cached feed", url)
+		return cached.feed, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, newStatusError(url, resp)
+	}
+
+	start = time.Now()
+	feed, err := gofeed.NewParser().ParseString(string(body))
+	c.parseDuration.WithLabelValues(url).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+
+	entry := &cachedFeed{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		feed:         feed,
+	}
+	c.mu.Lock()
+	if entry.etag != "" || entry.lastModified != "" {
+		c.cache[key] = entry
+	} else {
+		delete(c.cache, key)
+	}
+	c.mu.Unlock()
+
+	return feed, nil
+}
+
+// mediaType returns the media type of a Content-Type header without its
+// parameters, or the header as is when it cannot be parsed. Invalid UTF-8 is
+// replaced, as label values must be valid UTF-8.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToValidUTF8(contentType, "\uFFFD")
+	}
+	return mt
 }
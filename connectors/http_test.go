@@ -0,0 +1,177 @@
+package connectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"github.com/mmcdole/gofeed"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const feedXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Acme Status</title>
+    <item>
+      <title>API errors</title>
+      <guid>incident-1</guid>
+    </item>
+  </channel>
+</rss>`
+
+func TestHTTPConnectorConditionalRequests(t *testing.T) {
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match")+"|"+r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Fri, 13 Jun 2025 15:53:20 GMT")
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	first, err := c.Execute(HTTPQuery{URL: srv.URL})
+	require.NoError(t, err)
+	second, err := c.Execute(HTTPQuery{URL: srv.URL})
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.Equal(t, []string{"|", `"v1"|Fri, 13 Jun 2025 15:53:20 GMT`}, conditional)
+
+	expected := `
+# HELP rss_exporter_http_cache_hits_total Requests for a feed answered with 304 Not Modified and served from the cache
+# TYPE rss_exporter_http_cache_hits_total counter
+rss_exporter_http_cache_hits_total{url="` + srv.URL + `"} 1
+# HELP rss_exporter_http_last_status_code Status code of the latest response for a feed
+# TYPE rss_exporter_http_last_status_code gauge
+rss_exporter_http_last_status_code{url="` + srv.URL + `"} 304
+# HELP rss_exporter_http_requests_total HTTP requests made for a feed, by response status code
+# TYPE rss_exporter_http_requests_total counter
+rss_exporter_http_requests_total{code="200",url="` + srv.URL + `"} 1
+rss_exporter_http_requests_total{code="304",url="` + srv.URL + `"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"rss_exporter_http_cache_hits_total", "rss_exporter_http_last_status_code", "rss_exporter_http_requests_total"))
+	assert.Equal(t, float64(len(feedXML)), testutil.ToFloat64(c.bytes))
+}
+
+func TestHTTPConnectorCachePerClientConfig(t *testing.T) {
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("X-Tenant")+"|"+r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"`+r.Header.Get("X-Tenant")+`"`)
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	for _, tenant := range []string{"a", "b", "a"} {
+		_, err := c.Execute(HTTPQuery{URL: srv.URL, HTTPClient: &maas.HTTPClientConfig{Headers: map[string]string{"X-Tenant": tenant}}})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"a|", "b|", `a|"a"`}, conditional)
+}
+
+func TestHTTPConnectorForget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	_, err := c.Execute(HTTPQuery{URL: srv.URL})
+	require.NoError(t, err)
+	require.Contains(t, c.cache, cacheKey{url: srv.URL})
+
+	c.Forget(srv.URL)
+
+	assert.Empty(t, c.cache)
+	assert.Equal(t, 0, testutil.CollectAndCount(c))
+}
+
+func TestHTTPConnectorResponseMetrics(t *testing.T) {
+	contentType := "application/rss+xml; charset=utf-8"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	_, err := c.Execute(HTTPQuery{URL: srv.URL})
+	require.NoError(t, err)
+
+	// Only the latest content type is reported.
+	contentType = "text/xml"
+	_, err = c.Execute(HTTPQuery{URL: srv.URL})
+	require.NoError(t, err)
+
+	expected := `
+# HELP rss_exporter_http_content_type_info Media type of the latest response for a feed, value is always 1
+# TYPE rss_exporter_http_content_type_info gauge
+rss_exporter_http_content_type_info{content_type="text/xml",url="` + srv.URL + `"} 1
+# HELP rss_exporter_http_last_response_bytes Size of the body of the latest response for a feed
+# TYPE rss_exporter_http_last_response_bytes gauge
+rss_exporter_http_last_response_bytes{url="` + srv.URL + `"} ` + strconv.Itoa(len(feedXML)) + `
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"rss_exporter_http_content_type_info", "rss_exporter_http_last_response_bytes"))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.fetchDuration))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.parseDuration))
+}
+
+func TestHTTPConnectorMalformedContentType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/\xff\xfe")
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	require.NotPanics(t, func() {
+		_, err := c.Execute(HTTPQuery{URL: srv.URL})
+		require.NoError(t, err)
+	})
+
+	expected := `
+# HELP rss_exporter_http_content_type_info Media type of the latest response for a feed, value is always 1
+# TYPE rss_exporter_http_content_type_info gauge
+rss_exporter_http_content_type_info{content_type="text/` + "\uFFFD" + `",url="` + srv.URL + `"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "rss_exporter_http_content_type_info"))
+}
+
+func TestHTTPConnectorWithoutValidators(t *testing.T) {
+	var conditional int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			conditional++
+		}
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	for i := 0; i < 2; i++ {
+		res, err := c.Execute(HTTPQuery{URL: srv.URL})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Status", res.(*gofeed.Feed).Title)
+	}
+	assert.Zero(t, conditional)
+}
@@ -0,0 +1,243 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultBaseBackoff = time.Second
+	defaultMaxBackoff  = 30 * time.Second
+	defaultJitter      = 0.2
+)
+
+// defaultRetryOn are the status codes retried unless a policy lists its own.
+var defaultRetryOn = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Outcomes of a single attempt, reported by rss_exporter_http_attempts_total.
+const (
+	attemptSuccess  = "success"
+	attemptRetry    = "retry"
+	attemptError    = "error"
+	attemptDeadline = "deadline"
+)
+
+// retryPolicy is a maas.RetryPolicy with defaults applied.
+type retryPolicy struct {
+	maxAttempts       int
+	baseBackoff       time.Duration
+	maxBackoff        time.Duration
+	jitter            float64
+	retryOn           []int
+	respectRetryAfter bool
+}
+
+func newRetryPolicy(cfg *maas.RetryPolicy) retryPolicy {
+	p := retryPolicy{
+		maxAttempts:       defaultFetchRetries,
+		baseBackoff:       defaultBaseBackoff,
+		maxBackoff:        defaultMaxBackoff,
+		jitter:            defaultJitter,
+		retryOn:           defaultRetryOn,
+		respectRetryAfter: true,
+	}
+	if cfg == nil {
+		return p
+	}
+
+	if cfg.MaxAttempts > 0 {
+		p.maxAttempts = cfg.MaxAttempts
+	}
+	if cfg.BaseBackoff > 0 {
+		p.baseBackoff = cfg.BaseBackoff
+	}
+	if cfg.MaxBackoff > 0 {
+		p.maxBackoff = cfg.MaxBackoff
+	}
+	if cfg.Jitter > 0 {
+		p.jitter = cfg.Jitter
+	}
+	if cfg.RetryOn != nil {
+		p.retryOn = cfg.RetryOn
+	}
+	if cfg.RespectRetryAfter != nil {
+		p.respectRetryAfter = *cfg.RespectRetryAfter
+	}
+	return p
+}
+
+// ValidateRetryPolicy reports settings of cfg that cannot be used.
+func ValidateRetryPolicy(cfg *maas.RetryPolicy) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts %d must not be negative", cfg.MaxAttempts)
+	}
+	if cfg.BaseBackoff < 0 || cfg.MaxBackoff < 0 {
+		return errors.New("backoff must not be negative")
+	}
+	if cfg.BaseBackoff > 0 && cfg.MaxBackoff > 0 && cfg.BaseBackoff > cfg.MaxBackoff {
+		return fmt.Errorf("base_backoff %s exceeds max_backoff %s", cfg.BaseBackoff, cfg.MaxBackoff)
+	}
+	if cfg.Jitter < 0 || cfg.Jitter > 1 {
+		return fmt.Errorf("jitter %g must be between 0 and 1", cfg.Jitter)
+	}
+	for _, code := range cfg.RetryOn {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("retry_on: invalid status code %d", code)
+		}
+	}
+	return nil
+}
+
+// statusError is returned for a response with an unexpected status code.
+type statusError struct {
+	url        string
+	status     string
+	code       int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %s", e.url, e.status)
+}
+
+func newStatusError(url string, resp *http.Response) *statusError {
+	return &statusError{
+		url:        url,
+		status:     resp.Status,
+		code:       resp.StatusCode,
+		retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// transientError marks a failure, such as a connection reset, that may not
+// happen again.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. Missing or invalid values return zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// retryable reports whether err is worth another attempt, and for how long
+// the server asked to wait.
+func (p retryPolicy) retryable(err error) (bool, time.Duration) {
+	var se *statusError
+	if errors.As(err, &se) {
+		for _, code := range p.retryOn {
+			if code == se.code {
+				if p.respectRetryAfter {
+					return true, se.retryAfter
+				}
+				return true, 0
+			}
+		}
+		return false, 0
+	}
+
+	var te *transientError
+	return errors.As(err, &te), 0
+}
+
+// backoff returns the wait before attempt n+1, n starting at 1.
+func (p retryPolicy) backoff(n int) time.Duration {
+	wait := p.baseBackoff
+	for i := 1; i < n && wait < p.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.maxBackoff {
+		wait = p.maxBackoff
+	}
+	if p.jitter > 0 {
+		wait -= time.Duration(p.jitter * rand.Float64() * float64(wait))
+	}
+	return wait
+}
+
+// do calls attempt until it succeeds, fails with an error that is not worth
+// retrying or the attempts are used up. It never waits past the deadline of
+// ctx: when the next attempt could not start in time the last error is
+// returned straight away. observe, when set, receives the outcome of every
+// attempt.
+func (p retryPolicy) do(ctx context.Context, logger *logrus.Entry, observe func(outcome string), attempt func(ctx context.Context) error) error {
+	if observe == nil {
+		observe = func(string) {}
+	}
+
+	var lastErr error
+	for n := 1; n <= p.maxAttempts; n++ {
+		err := attempt(ctx)
+		if err == nil {
+			observe(attemptSuccess)
+			return nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			observe(attemptDeadline)
+			return lastErr
+		}
+
+		retry, retryAfter := p.retryable(err)
+		if !retry || n == p.maxAttempts {
+			observe(attemptError)
+			return lastErr
+		}
+
+		wait := p.backoff(n)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			observe(attemptDeadline)
+			logger.Debugf("attempt %d failed, no time left to retry: %v", n, err)
+			return lastErr
+		}
+
+		observe(attemptRetry)
+		logger.Debugf("attempt %d failed, retrying in %s: %v", n, wait, err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return lastErr
+		case <-timer.C:
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,188 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 6, 13, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Fri, 13 Jun 2025 15:01:30 GMT", now))
+	assert.Zero(t, parseRetryAfter("Fri, 13 Jun 2025 14:00:00 GMT", now))
+	assert.Zero(t, parseRetryAfter("-1", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("", now))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := newRetryPolicy(&maas.RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second, Jitter: 0.5})
+
+	for n, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		wait := p.backoff(n)
+		assert.LessOrEqual(t, wait, max, "attempt %d", n)
+		assert.GreaterOrEqual(t, wait, max/2, "attempt %d", n)
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	unavailable := &statusError{url: "u", status: "503 Service Unavailable", code: 503}
+	notFound := &statusError{url: "u", status: "404 Not Found", code: 404}
+	throttled := &statusError{url: "u", status: "429 Too Many Requests", code: 429, retryAfter: time.Hour}
+	reset := &transientError{err: errors.New("connection reset by peer")}
+	invalid := errors.New("failed to detect feed type")
+	no := false
+
+	tests := []struct {
+		name     string
+		policy   *maas.RetryPolicy
+		timeout  time.Duration
+		errs     []error
+		calls    int
+		outcomes []string
+	}{
+		{
+			name:     "Success after retries",
+			errs:     []error{unavailable, reset, nil},
+			calls:    3,
+			outcomes: []string{"retry", "retry", "success"},
+		},
+		{
+			name:     "Attempts used up",
+			errs:     []error{unavailable, unavailable, unavailable, nil},
+			calls:    3,
+			outcomes: []string{"retry", "retry", "error"},
+		},
+		{
+			name:     "Status not retried",
+			errs:     []error{notFound, nil},
+			calls:    1,
+			outcomes: []string{"error"},
+		},
+		{
+			name:     "Invalid feed not retried",
+			errs:     []error{invalid, nil},
+			calls:    1,
+			outcomes: []string{"error"},
+		},
+		{
+			name:     "Custom status codes",
+			policy:   &maas.RetryPolicy{RetryOn: []int{404}},
+			errs:     []error{notFound, unavailable, nil},
+			calls:    2,
+			outcomes: []string{"retry", "error"},
+		},
+		{
+			name:     "Retry-After beyond deadline",
+			timeout:  time.Second,
+			errs:     []error{throttled, nil},
+			calls:    1,
+			outcomes: []string{"deadline"},
+		},
+		{
+			name:     "Retry-After ignored",
+			policy:   &maas.RetryPolicy{RespectRetryAfter: &no},
+			timeout:  time.Second,
+			errs:     []error{throttled, nil},
+			calls:    2,
+			outcomes: []string{"retry", "success"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tt.policy
+			if policy == nil {
+				policy = &maas.RetryPolicy{}
+			}
+			policy.BaseBackoff, policy.MaxBackoff = time.Millisecond, time.Millisecond
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			var outcomes []string
+			err := newRetryPolicy(policy).do(ctx, logrus.NewEntry(logrus.New()), func(o string) {
+				outcomes = append(outcomes, o)
+			}, func(context.Context) error {
+				calls++
+				return tt.errs[calls-1]
+			})
+
+			assert.Equal(t, tt.calls, calls)
+			assert.Equal(t, tt.outcomes, outcomes)
+			if tt.outcomes[len(tt.outcomes)-1] == "success" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPConnectorRetries(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPConnector()
+	retry := &maas.RetryPolicy{BaseBackoff: time.Millisecond}
+	_, err := c.ExecuteContext(context.Background(), HTTPQuery{URL: srv.URL, Retry: retry})
+	require.NoError(t, err)
+
+	expected := `
+# HELP rss_exporter_http_attempts_total Request attempts for a feed or Statuspage page, by outcome
+# TYPE rss_exporter_http_attempts_total counter
+rss_exporter_http_attempts_total{outcome="retry",url="` + srv.URL + `"} 1
+rss_exporter_http_attempts_total{outcome="success",url="` + srv.URL + `"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "rss_exporter_http_attempts_total"))
+}
+
+func TestHTTPConnectorDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	begin := time.Now()
+	_, err := NewHTTPConnector().ExecuteContext(ctx, HTTPQuery{URL: srv.URL})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(begin), time.Second)
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	assert.NoError(t, ValidateRetryPolicy(nil))
+	assert.NoError(t, ValidateRetryPolicy(&maas.RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Second, MaxBackoff: time.Minute, Jitter: 0.5, RetryOn: []int{429}}))
+	assert.ErrorContains(t, ValidateRetryPolicy(&maas.RetryPolicy{MaxAttempts: -1}), "max_attempts")
+	assert.ErrorContains(t, ValidateRetryPolicy(&maas.RetryPolicy{BaseBackoff: time.Minute, MaxBackoff: time.Second}), "exceeds max_backoff")
+	assert.ErrorContains(t, ValidateRetryPolicy(&maas.RetryPolicy{Jitter: 2}), "jitter")
+	assert.ErrorContains(t, ValidateRetryPolicy(&maas.RetryPolicy{RetryOn: []int{42}}), "invalid status code")
+}
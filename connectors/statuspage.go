@@ -0,0 +1,195 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// statuspageSummaryPath is the Statuspage API endpoint that lists the
+// components, unresolved incidents and upcoming or in progress maintenance of
+// a page in a single document.
+const statuspageSummaryPath = "/api/v2/summary.json"
+
+// StatuspageConnector implements maas.Connector for the public API of pages
+// hosted by Atlassian Statuspage.
+type StatuspageConnector struct {
+	Client *http.Client
+	Logger *logrus.Entry
+
+	clients  clientCache
+	attempts *prometheus.CounterVec
+}
+
+// NewStatuspageConnector creates a new Statuspage API connector.
+func NewStatuspageConnector() *StatuspageConnector {
+	return &StatuspageConnector{
+		Client: defaultHTTPClient(),
+		Logger: logrus.WithField("component", "statuspage_connector"),
+	}
+}
+
+// StatuspageQuery requests the summary of the page at URL, such as
+// https://www.cloudflarestatus.com, with the client configured by HTTPClient
+// and the retry policy Retry when set.
+type StatuspageQuery struct {
+	URL        string
+	HTTPClient *maas.HTTPClientConfig
+	Retry      *maas.RetryPolicy
+}
+
+// StatuspageSummary is the document returned by /api/v2/summary.json.
+type StatuspageSummary struct {
+	Page                  StatuspagePage        `json:"page"`
+	Status                StatuspageStatus      `json:"status"`
+	Components            []StatuspageComponent `json:"components"`
+	Incidents             []StatuspageIncident  `json:"incidents"`
+	ScheduledMaintenances []StatuspageIncident  `json:"scheduled_maintenances"`
+}
+
+// StatuspagePage describes the page itself.
+type StatuspagePage struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StatuspageStatus is the overall status of the page.
+type StatuspageStatus struct {
+	Indicator   string `json:"indicator"`
+	Description string `json:"description"`
+}
+
+// StatuspageComponent is a component, or a group of components, of the page.
+// Status is one of operational, under_maintenance, degraded_performance,
+// partial_outage or major_outage.
+type StatuspageComponent struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	GroupID  string `json:"group_id"`
+	Group    bool   `json:"group"`
+	Position int    `json:"position"`
+}
+
+// StatuspageIncident is an incident or a scheduled maintenance. Impact is one
+// of none, minor, major, critical or maintenance.
+type StatuspageIncident struct {
+	ID              string                     `json:"id"`
+	Name            string                     `json:"name"`
+	Status          string                     `json:"status"`
+	Impact          string                     `json:"impact"`
+	Shortlink       string                     `json:"shortlink"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+	StartedAt       *time.Time                 `json:"started_at"`
+	ResolvedAt      *time.Time                 `json:"resolved_at"`
+	ScheduledFor    *time.Time                 `json:"scheduled_for"`
+	ScheduledUntil  *time.Time                 `json:"scheduled_until"`
+	Components      []StatuspageComponent      `json:"components"`
+	IncidentUpdates []StatuspageIncidentUpdate `json:"incident_updates"`
+}
+
+// StatuspageIncidentUpdate is a single update posted to an incident.
+type StatuspageIncidentUpdate struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Connect implements the maas.Connector interface (no-op for Statuspage)
+func (c *StatuspageConnector) Connect() error {
+	return nil
+}
+
+// Flags implements the maas.Connector interface (no flags needed for Statuspage)
+func (c *StatuspageConnector) Flags(a *kingpin.Application) {
+	// No flags needed for Statuspage connector
+}
+
+// Execute fetches the summary of a page and returns a *StatuspageSummary.
+func (c *StatuspageConnector) Execute(query interface{}) (interface{}, error) {
+	return c.ExecuteContext(context.Background(), query)
+}
+
+// ExecuteContext is Execute bounded by ctx, retrying failed requests with the
+// retry policy of the query.
+func (c *StatuspageConnector) ExecuteContext(ctx context.Context, query interface{}) (interface{}, error) {
+	q, ok := query.(StatuspageQuery)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query %T", query)
+	}
+
+	url := summaryURL(q.URL)
+	client, err := c.clients.get(c.Client, q.HTTPClient)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+
+	var summary *StatuspageSummary
+	observe := func(outcome string) {
+		if c.attempts != nil {
+			c.attempts.WithLabelValues(url, outcome).Inc()
+		}
+	}
+	err = newRetryPolicy(q.Retry).do(ctx, c.Logger, observe, func(ctx context.Context) error {
+		var err error
+		summary, err = c.fetch(ctx, client, url)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Debugf("%s: %d components, %d incidents, %d maintenances", url,
+		len(summary.Components), len(summary.Incidents), len(summary.ScheduledMaintenances))
+
+	return summary, nil
+}
+
+// Forget drops the metrics of the page at url once no service is scraping it
+// any more.
+func (c *StatuspageConnector) Forget(url string) {
+	if c.attempts != nil {
+		c.attempts.DeletePartialMatch(prometheus.Labels{"url": summaryURL(url)})
+	}
+}
+
+// summaryURL returns the URL of the summary of the page at url.
+func summaryURL(url string) string {
+	return strings.TrimSuffix(url, "/") + statuspageSummaryPath
+}
+
+// fetch requests the summary at url once.
+func (c *StatuspageConnector) fetch(ctx context.Context, client *http.Client, url string) (*StatuspageSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &transientError{err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(url, resp)
+	}
+
+	var summary StatuspageSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+
+	return &summary, nil
+}
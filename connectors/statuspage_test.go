@@ -0,0 +1,71 @@
+package connectors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	maas "github.com/mbrown007/monitoring-rss-exporter/monitoring-maas"
+)
+
+const summaryJSON = `{
+  "page": {"id": "p1", "name": "Acme", "url": "https://status.acme.example"},
+  "status": {"indicator": "minor", "description": "Minor Service Outage"},
+  "components": [{"id": "c1", "name": "API", "status": "partial_outage", "group": false}],
+  "incidents": [{
+    "id": "i1", "name": "API errors", "status": "investigating", "impact": "major",
+    "created_at": "2025-06-12T22:10:00.000Z", "updated_at": "2025-06-12T22:20:00.000Z",
+    "components": [{"id": "c1", "name": "API", "status": "partial_outage"}],
+    "incident_updates": [{"id": "u1", "status": "investigating", "body": "Looking into it.", "created_at": "2025-06-12T22:20:00.000Z"}]
+  }],
+  "scheduled_maintenances": []
+}`
+
+func TestStatuspageConnector(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/summary.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(summaryJSON))
+	}))
+	defer srv.Close()
+
+	res, err := NewStatuspageConnector().Execute(StatuspageQuery{URL: srv.URL + "/"})
+	require.NoError(t, err)
+
+	summary := res.(*StatuspageSummary)
+	assert.Equal(t, "Acme", summary.Page.Name)
+	assert.Equal(t, "minor", summary.Status.Indicator)
+	require.Len(t, summary.Components, 1)
+	assert.Equal(t, "partial_outage", summary.Components[0].Status)
+	require.Len(t, summary.Incidents, 1)
+	assert.Equal(t, "major", summary.Incidents[0].Impact)
+	assert.Equal(t, "Looking into it.", summary.Incidents[0].IncidentUpdates[0].Body)
+}
+
+func TestStatuspageConnectorErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken/api/v2/summary.json" {
+			_, _ = w.Write([]byte("<html>"))
+			return
+		}
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewStatuspageConnector()
+
+	_, err := c.Execute(StatuspageQuery{URL: srv.URL, Retry: &maas.RetryPolicy{MaxAttempts: 1}})
+	assert.ErrorContains(t, err, "503")
+
+	_, err = c.Execute(StatuspageQuery{URL: srv.URL + "/broken"})
+	assert.ErrorContains(t, err, "/broken/api/v2/summary.json")
+
+	_, err = c.Execute(HTTPQuery{URL: srv.URL})
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package maas
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -12,6 +13,51 @@ type Connector interface {
 	Flags(a *kingpin.Application)
 }
 
+// ContextConnector is implemented by connectors that abort a command when ctx
+// is done.
+type ContextConnector interface {
+	Connector
+	ExecuteContext(ctx context.Context, command interface{}) (interface{}, error)
+}
+
+// AdaptConnector returns c when it implements ContextConnector. Other
+// connectors are wrapped so ExecuteContext returns as soon as ctx is done; the
+// command itself runs to completion in the background and its result is
+// dropped.
+func AdaptConnector(c Connector) ContextConnector {
+	if cc, ok := c.(ContextConnector); ok {
+		return cc
+	}
+	return connectorAdapter{c}
+}
+
+type connectorAdapter struct {
+	Connector
+}
+
+func (a connectorAdapter) ExecuteContext(ctx context.Context, command interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		res interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := a.Execute(command)
+		done <- result{res, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.res, r.err
+	}
+}
+
 type UnableToConnectError struct {
 	Err error
 }
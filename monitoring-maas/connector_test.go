@@ -0,0 +1,86 @@
+package maas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// blockingConnector answers once release is closed.
+type blockingConnector struct {
+	SuccessConnector
+	release chan struct{}
+}
+
+func (c *blockingConnector) Execute(interface{}) (interface{}, error) {
+	<-c.release
+	return "done", nil
+}
+
+// blockingScraper returns once release is closed.
+type blockingScraper struct {
+	release chan struct{}
+}
+
+func (s blockingScraper) Scrape(c Connector) ([]Metric, error) {
+	<-s.release
+	return []Metric{NewMetric("m", 0, 1, nil)}, nil
+}
+
+type AdapterTestSuite struct {
+	suite.Suite
+}
+
+func (s *AdapterTestSuite) TestAdaptConnector() {
+	c := &blockingConnector{release: make(chan struct{})}
+	cc := AdaptConnector(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := cc.ExecuteContext(ctx, nil)
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	close(c.release)
+	res, err := cc.ExecuteContext(context.Background(), nil)
+	s.NoError(err)
+	s.Equal("done", res)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cc.ExecuteContext(cancelled, nil)
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *AdapterTestSuite) TestAdaptConnectorKeepsContextConnector() {
+	cc := &contextConnector{}
+	s.Same(cc, AdaptConnector(cc))
+}
+
+func (s *AdapterTestSuite) TestAdaptScraper() {
+	sc := blockingScraper{release: make(chan struct{})}
+	cs := AdaptScraper(sc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := cs.ScrapeContext(ctx, &SuccessConnector{})
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	close(sc.release)
+	metrics, err := cs.ScrapeContext(context.Background(), &SuccessConnector{})
+	s.NoError(err)
+	s.Len(metrics, 1)
+}
+
+type contextConnector struct {
+	SuccessConnector
+}
+
+func (c *contextConnector) ExecuteContext(ctx context.Context, command interface{}) (interface{}, error) {
+	return c.Execute(command)
+}
+
+func TestAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(AdapterTestSuite))
+}
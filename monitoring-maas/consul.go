@@ -9,9 +9,12 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 )
 
+// Advertiser registers the exporter with a service discovery backend so that
+// Prometheus finds it, and withdraws the registration on shutdown.
 type Advertiser interface {
 	Flags(a *kingpin.Application)
 	Advertise(address string, port int, labels Labeler) error
+	Deregister() error
 }
 
 type HealthCheck struct {
@@ -24,8 +27,11 @@ type Consul struct {
 	config      *consul.Config
 	client      *consul.Client
 	healthCheck HealthCheck
+	serviceID   string
 }
 
+// NewConsul returns an advertiser registering the exporter with the local
+// Consul agent. The client is only created by the first call to Advertise.
 func NewConsul(options ...func(*Consul)) *Consul {
 	c := &Consul{
 		config: consul.DefaultConfig(),
@@ -33,15 +39,6 @@ func NewConsul(options ...func(*Consul)) *Consul {
 
 	c.apply(options)
 
-	cl, err := consul.NewClient(c.config)
-
-	if err != nil {
-		log.Errorf("unable to create client: %s", err)
-		return nil
-	}
-
-	c.client = cl
-
 	return c
 }
 
@@ -54,8 +51,18 @@ func (c *Consul) Flags(a *kingpin.Application) {
 }
 
 func (c *Consul) Advertise(address string, port int, labels Labeler) error {
-	return c.client.Agent().ServiceRegister(&consul.AgentServiceRegistration{
-		Name:    fmt.Sprintf("%s:%d", address, port),
+	if c.client == nil {
+		cl, err := consul.NewClient(c.config)
+		if err != nil {
+			return fmt.Errorf("unable to create client: %w", err)
+		}
+		c.client = cl
+	}
+
+	name := fmt.Sprintf("%s:%d", address, port)
+
+	err := c.client.Agent().ServiceRegister(&consul.AgentServiceRegistration{
+		Name:    name,
 		Address: address,
 		Port:    port,
 		Tags:    c.tags(labels),
@@ -66,6 +73,29 @@ func (c *Consul) Advertise(address string, port int, labels Labeler) error {
 			DeregisterCriticalServiceAfter: c.healthCheck.deregister.String(),
 		},
 	})
+	if err != nil {
+		return err
+	}
+
+	// Without an explicit ID the agent uses the name as the service ID.
+	c.serviceID = name
+
+	return nil
+}
+
+// Deregister removes the service registered by Advertise, if any.
+func (c *Consul) Deregister() error {
+	if c.serviceID == "" {
+		return nil
+	}
+
+	if err := c.client.Agent().ServiceDeregister(c.serviceID); err != nil {
+		return err
+	}
+	log.Infof("deregistered %s from consul", c.serviceID)
+	c.serviceID = ""
+
+	return nil
 }
 
 func (c *Consul) tags(l Labeler) []string {
@@ -78,6 +78,12 @@ func (s *ConsulTestSuite) TestRegisters() {
 		s.Contains(svc.Tags, tc.key)
 	}
 
+	s.NoError(c.Deregister())
+
+	svcs, err = cs.Agent().Services()
+	s.NoError(err)
+	s.Empty(svcs)
+
 	defer srv.Stop()
 }
 
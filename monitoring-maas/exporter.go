@@ -7,8 +7,11 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	_ "net/http/pprof"
@@ -22,6 +25,12 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 )
 
+// defaultGracePeriod bounds Shutdown when it is started by Run.
+const defaultGracePeriod = 25 * time.Second
+
+// defaultMaxConcurrency is the number of scrapes allowed to run at once.
+const defaultMaxConcurrency = 10
+
 type Exporter struct {
 	application       *kingpin.Application
 	scheduler         Scheduler
@@ -30,11 +39,14 @@ type Exporter struct {
 	logLevel          string
 	listenAddress     string
 	listenPort        int
+	configured        map[string]string
 	telemetryPath     string
 	labels            Labeler
 	shouldAdvertise   bool
 	shouldDescribe    bool
 	advertiser        Advertiser
+	advertisers       map[string]Advertiser
+	advertiseBackend  string
 	scrapeFrequency   *prometheus.GaugeVec
 	scrapeSuccess     *prometheus.GaugeVec
 	scrapeTotal       *prometheus.CounterVec
@@ -42,23 +54,59 @@ type Exporter struct {
 	scrapeTimeouts    *prometheus.CounterVec
 	scrapeDuration    *prometheus.HistogramVec
 	scrapeLastSuccess *prometheus.GaugeVec
+	reloader          Reloader
+	reloadSuccess     prometheus.Gauge
+	reloadTimestamp   prometheus.Gauge
+	reloadMu          sync.Mutex
+	stopping          bool
+	mu                sync.RWMutex
+	started           bool
 	scheduledscrapers []*ScheduledScraper
+	entries           map[string]cron.EntryID
 	metrics           map[string]*Metrics
 	registry          *prometheus.Registry
+	scrapeCtx         context.Context
+	cancelScrapes     context.CancelFunc
+	startCtx          context.Context
+	cancelStart       context.CancelFunc
+	server            *http.Server
+	gracePeriod       time.Duration
+	maxConcurrency    int
+	startupJitter     bool
+	offsets           map[string]time.Duration
+	slots             chan struct{}
+	scrapesInFlight   prometheus.Gauge
+	initialScrapes    sync.WaitGroup
+	status            map[string]*scraperStatus
+	ready             chan struct{}
 }
 
 func NewExporter(a *kingpin.Application, c Connector, options ...func(*Exporter)) (*Exporter, error) {
 	var metricLabels = []string{"exporter", "scraper"}
+	scrapeCtx, cancelScrapes := context.WithCancel(context.Background())
+	startCtx, cancelStart := context.WithCancel(scrapeCtx)
 
 	e := &Exporter{
 		application:       a,
 		connector:         c,
 		args:              os.Args[1:],
+		listenAddress:     "127.0.0.1",
+		logLevel:          "error",
+		configured:        make(map[string]string),
 		scheduler:         cron.New(cron.WithSeconds()),
+		entries:           make(map[string]cron.EntryID),
+		offsets:           make(map[string]time.Duration),
 		metrics:           make(map[string]*Metrics),
 		registry:          prometheus.NewRegistry(),
+		scrapeCtx:         scrapeCtx,
+		cancelScrapes:     cancelScrapes,
+		startCtx:          startCtx,
+		cancelStart:       cancelStart,
 		labels:            NewDefaultLabels(),
-		advertiser:        NewConsul(),
+		gracePeriod:       defaultGracePeriod,
+		maxConcurrency:    defaultMaxConcurrency,
+		status:            make(map[string]*scraperStatus),
+		ready:             make(chan struct{}),
 		scrapeFrequency:   prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "maas_scrape_frequency_seconds", Help: "Scrape frequency"}, metricLabels),
 		scrapeSuccess:     prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "maas_scrape_success", Help: "Was the last scrape successful"}, metricLabels),
 		scrapeLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "maas_scrape_last_success_seconds", Help: "When the last successful scrape was"}, metricLabels),
@@ -66,6 +114,9 @@ func NewExporter(a *kingpin.Application, c Connector, options ...func(*Exporter)
 		scrapeTimeouts:    prometheus.NewCounterVec(prometheus.CounterOpts{Name: "maas_scrape_timeout_total", Help: "Total number of scrapes that have timed out"}, metricLabels),
 		scrapeFails:       prometheus.NewCounterVec(prometheus.CounterOpts{Name: "maas_scrape_failed_total", Help: "Total number of failed scrapes"}, metricLabels),
 		scrapeDuration:    prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "maas_scrape_duration", Help: "Scrape Duration"}, metricLabels),
+		reloadSuccess:     prometheus.NewGauge(prometheus.GaugeOpts{Name: "maas_config_last_reload_successful", Help: "Whether the last configuration reload attempt was successful"}),
+		reloadTimestamp:   prometheus.NewGauge(prometheus.GaugeOpts{Name: "maas_config_last_reload_success_timestamp_seconds", Help: "Timestamp of the last successful configuration reload"}),
+		scrapesInFlight:   prometheus.NewGauge(prometheus.GaugeOpts{Name: "maas_scrapes_in_flight", Help: "Number of scrapes running"}),
 	}
 	e.apply(options)
 
@@ -73,15 +124,24 @@ func NewExporter(a *kingpin.Application, c Connector, options ...func(*Exporter)
 		return nil, err
 	}
 
+	if err := e.checkOverrides(); err != nil {
+		return nil, err
+	}
+
 	e.configureLogger()
 
 	if err := c.Connect(); err != nil {
 		return nil, UnableToConnectError{Err: err}
 	}
 
+	if e.maxConcurrency > 0 {
+		e.slots = make(chan struct{}, e.maxConcurrency)
+	}
+
 	if err := e.schedule(); err != nil {
 		return nil, err
 	}
+	e.updateReady()
 
 	log.Infof("Starting %s_exporter: %s\n", e.application.Name, version.Info())
 	log.Infoln("Build context", version.BuildContext())
@@ -97,41 +157,297 @@ func NewExporter(a *kingpin.Application, c Connector, options ...func(*Exporter)
 		e.scrapeLastSuccess,
 		e.scrapeDuration,
 		e.scrapeTimeouts,
+		e.scrapesInFlight,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "maas_ready", Help: "Whether every enabled scraper has completed its first scrape"}, func() float64 {
+			if e.Ready() {
+				return 1
+			}
+			return 0
+		}),
 	)
 
+	// Connectors may export metrics of their own, such as request counts.
+	if collector, ok := c.(prometheus.Collector); ok {
+		e.registry.MustRegister(collector)
+	}
+
+	if e.reloader != nil {
+		e.reloadSuccess.Set(1)
+		e.reloadTimestamp.SetToCurrentTime()
+		e.registry.MustRegister(e.reloadSuccess, e.reloadTimestamp)
+	}
+
 	return e, nil
 }
 
+// Start runs the first scrape of every scraper in the background and starts
+// the scheduler. Unless --no-scrape.startup-jitter is given, each first scrape
+// is delayed by the random offset of the scraper, as its scheduled ones are.
+// Start returns straight away: use Ready or WaitReady to learn when each
+// scraper has been scraped once.
 func (e *Exporter) Start() {
-	for _, s := range e.scheduler.Entries() {
-		log.Debugf("Running Job %d", s.ID)
-		s.Job.Run()
+	e.mu.Lock()
+	for _, s := range e.scheduledscrapers {
+		if _, ok := e.entries[s.name]; ok {
+			var delay time.Duration
+			if e.startupJitter {
+				delay = e.offsets[s.name]
+			}
+			e.scrapeInBackground(s, delay)
+		}
 	}
+	e.started = true
+	e.mu.Unlock()
 
 	e.scheduler.Start()
 }
 
+// Ready reports whether every enabled scraper has completed at least one
+// scrape, successful or not.
+func (e *Exporter) Ready() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	select {
+	case <-e.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitReady blocks until the exporter is ready or ctx is done.
+func (e *Exporter) WaitReady(ctx context.Context) error {
+	e.mu.RLock()
+	ready := e.ready
+	e.mu.RUnlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// updateReady opens or closes the ready channel to match the scrapers
+// attempted so far. It must be called with e.mu held.
+func (e *Exporter) updateReady() {
+	ready := true
+	for name := range e.entries {
+		if _, ok := e.status[name]; !ok {
+			ready = false
+			break
+		}
+	}
+
+	select {
+	case <-e.ready:
+		if !ready {
+			e.ready = make(chan struct{})
+		}
+	default:
+		if ready {
+			close(e.ready)
+		}
+	}
+}
+
+// Serve runs the exporter until the process exits. Use Run to shut down
+// gracefully.
 func (e *Exporter) Serve() {
-	err := e.advertise()
+	if err := e.Run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	if err != nil {
-		log.Fatalf("unable to advertise exporter: %s", err)
+// Run advertises the exporter and serves its endpoints until ctx is done,
+// then calls Shutdown with the configured grace period.
+func (e *Exporter) Run(ctx context.Context) error {
+	if err := e.advertise(); err != nil {
+		return fmt.Errorf("unable to advertise exporter: %w", err)
+	}
+
+	e.server = &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", e.listenAddress, e.listenPort),
+		Handler: e.handler(),
+	}
+
+	// The reload triggers stop with ctx, and are waited for before shutting
+	// down so that no reload runs concurrently with Shutdown.
+	var triggers sync.WaitGroup
+	triggerCtx, stopTriggers := context.WithCancel(ctx)
+	defer stopTriggers()
+
+	if e.reloader != nil {
+		triggers.Add(1)
+		go func() {
+			defer triggers.Done()
+			e.reloadOnSignal(triggerCtx)
+		}()
+	}
+	if w, ok := e.reloader.(Watcher); ok {
+		triggers.Add(1)
+		go func() {
+			defer triggers.Done()
+			w.Watch(triggerCtx)
+		}()
+	}
+
+	log.Infof("starting exporter on http://%s%s", e.server.Addr, e.telemetryPath)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- e.server.ListenAndServe()
+	}()
+
+	var err error
+	select {
+	case err = <-errs:
+		err = fmt.Errorf("couldn't start HTTP server: %w", err)
+	case <-ctx.Done():
+		log.Infof("shutting down, waiting up to %s", e.gracePeriod)
+	}
+	stopTriggers()
+	triggers.Wait()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), e.gracePeriod)
+	defer cancel()
+
+	return errors.Join(err, e.Shutdown(shutdownCtx))
+}
+
+// Shutdown stops scheduling scrapes and waits for running ones, stops the
+// HTTP server once in-flight requests are answered, deregisters the exporter
+// and flushes pending Sentry events. Scrapes still running when ctx is done
+// are cancelled.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	// Wait for a reload in progress and refuse any later one.
+	e.reloadMu.Lock()
+	e.stopping = true
+	e.reloadMu.Unlock()
+
+	// First scrapes still waiting for their offset are not started.
+	e.cancelStart()
+
+	if err := e.deregister(); err != nil {
+		errs = append(errs, fmt.Errorf("unable to deregister exporter: %w", err))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		<-e.scheduler.Stop().Done()
+		e.initialScrapes.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("scrapes still running: %w", ctx.Err()))
 	}
+	e.CancelScrapes()
+
+	if e.server != nil {
+		if err := e.server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("unable to stop HTTP server: %w", err))
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		FlushSentry(time.Until(deadline))
+	}
+
+	return errors.Join(errs...)
+}
 
-	socket := fmt.Sprintf("%s:%d", e.listenAddress, e.listenPort)
+func (e *Exporter) handler() http.Handler {
+	mux := http.NewServeMux()
 
-	http.Handle(e.telemetryPath, promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{}))
+	mux.Handle(e.telemetryPath, promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{}))
 
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "PONG")
 	})
 
-	log.Infof("starting exporter on http://%s%s", socket, e.telemetryPath)
+	if e.reloader != nil {
+		mux.HandleFunc("/-/reload", e.handleReload)
+	}
 
-	err = http.ListenAndServe(socket, nil)
+	mux.HandleFunc("/-/ready", e.handleReady)
+	mux.HandleFunc("/-/healthy", e.handleHealthy)
 
-	if err != nil {
-		log.Fatalf("couldn't start HTTP server: %s", err)
+	// Advertisers serving the targets themselves, such as HTTPSD.
+	if h, ok := e.advertiser.(http.Handler); ok {
+		mux.Handle("/-/sd", h)
+	}
+
+	// The profiling handlers register themselves on the default mux.
+	mux.Handle("/debug/pprof/", http.DefaultServeMux)
+
+	return mux
+}
+
+// CancelScrapes aborts every running scrape, and makes any scrape started
+// afterwards fail straight away. It is meant for shutting down.
+func (e *Exporter) CancelScrapes() {
+	e.cancelScrapes()
+}
+
+// Reload asks the configured Reloader to apply a new configuration. Reloads
+// are serialised and their outcome is exported as metrics.
+func (e *Exporter) Reload() error {
+	if e.reloader == nil {
+		return errors.New("no reloader configured")
+	}
+
+	e.reloadMu.Lock()
+	defer e.reloadMu.Unlock()
+
+	if e.stopping {
+		return errors.New("exporter is shutting down")
+	}
+
+	if err := e.reloader.Reload(); err != nil {
+		e.reloadSuccess.Set(0)
+		log.Errorf("configuration reload failed: %s", err)
+		return err
+	}
+
+	e.reloadSuccess.Set(1)
+	e.reloadTimestamp.SetToCurrentTime()
+	log.Info("configuration reloaded")
+
+	return nil
+}
+
+func (e *Exporter) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "only POST or PUT requests allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := e.Reload(); err != nil {
+		http.Error(w, fmt.Sprintf("failed to reload config: %s", err), http.StatusInternalServerError)
+	}
+}
+
+// reloadOnSignal reloads the configuration on every SIGHUP until ctx is done.
+func (e *Exporter) reloadOnSignal(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-hup:
+			log.Info("received SIGHUP, reloading configuration")
+			_ = e.Reload()
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
@@ -146,6 +462,14 @@ func (e *Exporter) advertise() error {
 	return e.advertiser.Advertise(e.listenAddress, e.listenPort, e.labels)
 }
 
+func (e *Exporter) deregister() error {
+	if !e.shouldAdvertise || e.advertiser == nil {
+		return nil
+	}
+
+	return e.advertiser.Deregister()
+}
+
 func (e *Exporter) configureLogger() {
 	level, err := log.ParseLevel(e.logLevel)
 
@@ -171,12 +495,12 @@ func (e *Exporter) apply(options []func(*Exporter)) {
 	}
 }
 
-func (e Exporter) describe() {
+func (e *Exporter) describe() {
 	fmt.Print(e.parseMetrics().ToMarkdown())
 	os.Exit(0)
 }
 
-func (e Exporter) parseMetrics() Descriptions {
+func (e *Exporter) parseMetrics() Descriptions {
 	descs := make(Descriptions)
 
 	for _, s := range e.scheduledscrapers {
@@ -212,14 +536,13 @@ func (e *Exporter) flags() error {
 		"Produce documentation only",
 	).Default(strconv.FormatBool(false)).BoolVar(&e.shouldDescribe)
 
-	if e.advertiser != nil {
-		e.advertiser.Flags(e.application)
-	}
+	e.advertiserFlags()
 
 	e.connector.Flags(e.application)
 	e.labels.Flags(e.application)
 	e.webFlags()
 	e.logflags()
+	e.scrapeFlags()
 
 	for _, s := range e.scheduledscrapers {
 		s.Flags(e.application)
@@ -239,94 +562,376 @@ func (e *Exporter) flags() error {
 		return nil
 	})
 
-	_, err := e.application.Parse(e.args)
+	if _, err := e.application.Parse(e.args); err != nil {
+		return err
+	}
 
-	return err
+	if e.advertiser == nil && e.shouldAdvertise {
+		e.advertiser = e.advertisers[e.advertiseBackend]
+	}
+
+	return nil
+}
+
+// advertiserFlags adds the flags of the advertiser given with WithAdvertiser
+// or, without one, those of every built-in backend along with the flag
+// choosing between them. The backends do nothing until Advertise is called.
+func (e *Exporter) advertiserFlags() {
+	if e.advertiser != nil {
+		e.advertiser.Flags(e.application)
+		return
+	}
+
+	e.advertisers = map[string]Advertiser{
+		"consul": NewConsul(),
+		"file":   NewFileSD(),
+		"http":   NewHTTPSD(),
+	}
+	backends := []string{"consul", "file", "http"}
+
+	e.application.Flag(
+		"advertise.backend",
+		"Service discovery backend to advertise the exporter with: consul, file or http",
+	).Default("consul").EnumVar(&e.advertiseBackend, backends...)
+
+	for _, name := range backends {
+		e.advertisers[name].Flags(e.application)
+	}
 }
 
 func (e *Exporter) webFlags() {
 	e.application.Flag(
 		"web.listen-address",
 		"Address on which to expose metrics and web interface",
-	).Default("127.0.0.1").StringVar(&e.listenAddress)
+	).Default(e.listenAddress).StringVar(&e.listenAddress)
 
-	e.application.Flag(
+	port := e.application.Flag(
 		"web.listen-port",
 		"Port on which to expose metrics and web interface",
-	).Required().IntVar(&e.listenPort)
+	)
+	if e.listenPort != 0 {
+		port.Default(strconv.Itoa(e.listenPort))
+	} else {
+		port.Required()
+	}
+	port.IntVar(&e.listenPort)
 
 	e.application.Flag(
 		"web.telemetry-path",
 		"Path under which to expose metrics",
 	).Default("/metrics").StringVar(&e.telemetryPath)
+
+	e.application.Flag(
+		"web.shutdown-grace-period",
+		"Time allowed for running scrapes and requests to finish on shutdown",
+	).Default(e.gracePeriod.String()).DurationVar(&e.gracePeriod)
+}
+
+func (e *Exporter) scrapeFlags() {
+	e.application.Flag(
+		"scrape.max-concurrency",
+		"Maximum number of scrapes running at once. 0 means no limit",
+	).Default(strconv.Itoa(e.maxConcurrency)).IntVar(&e.maxConcurrency)
+
+	e.application.Flag(
+		"scrape.startup-jitter",
+		"Delay the first scrape of each scraper by its random schedule offset",
+	).Default(strconv.FormatBool(true)).BoolVar(&e.startupJitter)
 }
 
 func (e *Exporter) logflags() {
 	e.application.Flag(
 		"log.level",
 		"Only log messages with the given severity or above. Valid levels: [trace, debug, info, warn, error, fatal]",
-	).Default("error").StringVar(&e.logLevel)
+	).Default(e.logLevel).StringVar(&e.logLevel)
+}
+
+// checkOverrides returns an error for every setting supplied through an
+// option, such as one read from a configuration file, that a command line
+// flag or environment variable set to a different value.
+func (e *Exporter) checkOverrides() error {
+	var errs []error
+
+	for _, name := range []string{"web.listen-address", "web.listen-port", "log.level", "scrape.max-concurrency"} {
+		configured, ok := e.configured[name]
+		if !ok {
+			continue
+		}
+		if value := e.application.GetFlag(name).Model().Value.String(); !sameSetting(name, value, configured) {
+			errs = append(errs, fmt.Errorf("--%s=%s conflicts with configured value %s", name, value, configured))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sameSetting reports whether a and b are equivalent values of the named
+// setting. Log levels are compared as parsed by logrus, so case is ignored.
+func sameSetting(name, a, b string) bool {
+	if name == "log.level" {
+		la, errA := log.ParseLevel(a)
+		lb, errB := log.ParseLevel(b)
+		if errA == nil && errB == nil {
+			return la == lb
+		}
+	}
+	return a == b
 }
 
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	for _, m := range e.metrics {
 		m.Collect(ch)
 	}
 }
 
+// Describe sends no descriptors, making the exporter an unchecked collector:
+// the set of scrapers, and so of metrics, can change on reload.
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
-	prometheus.DescribeByCollect(e, ch)
 }
 
 func (e *Exporter) schedule() error {
-	for _, ss := range e.scheduledscrapers {
-		s := ss
-		if !s.schedule.isEnabled {
-			log.Warnf("Scraper %s is not enabled", s.name)
-			continue
+	for _, s := range e.scheduledscrapers {
+		if err := e.add(s); err != nil {
+			return err
 		}
+	}
 
-		e.metrics[s.name] = NewMetrics()
-		e.scrapeFrequency.WithLabelValues(e.application.Name, s.name).Set(s.schedule.frequency.Seconds())
-		e.scrapeFails.WithLabelValues(e.application.Name, s.name)
-		e.scrapeTotal.WithLabelValues(e.application.Name, s.name)
-		e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name)
-		id, err := e.scheduler.AddFunc(s.ScheduleSpec(), func() {
-			ctx, cancel := context.WithTimeout(context.Background(), s.schedule.timeout)
-			defer cancel()
+	return nil
+}
 
-			log.Tracef("Scraping %s", s.name)
+// AddScheduledScraper schedules a scraper on a running exporter. Once the
+// exporter has been started the first scrape runs immediately.
+func (e *Exporter) AddScheduledScraper(s *ScheduledScraper) error {
+	return e.ReplaceScheduledScrapers(nil, []*ScheduledScraper{s})
+}
 
-			begin := time.Now()
-			metrics, err := s.scraper.Scrape(e.connector)
-			e.scrapeDuration.WithLabelValues(e.application.Name, s.name).Observe(time.Since(begin).Seconds())
-			e.scrapeTotal.WithLabelValues(e.application.Name, s.name).Inc()
-			if err != nil {
-				log.Warnf("%s: scrape failed: %s", s.name, err)
-				e.scrapeFails.WithLabelValues(e.application.Name, s.name).Inc()
-				return
-			}
-			log.Tracef("%s: Received Metrics: %+v", s.name, metrics)
-			e.metrics[s.name].Put(e.convertMetrics(s, metrics))
-			select {
-			case <-ctx.Done():
-				e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name).Inc()
-				log.Warnf("%s: scrape timed out: %s", s.name, ctx.Err())
-			default:
-				e.scrapeLastSuccess.WithLabelValues(e.application.Name, s.name).SetToCurrentTime()
-			}
-		})
+// RemoveScheduledScraper stops scheduling the named scraper and drops the
+// metrics it exported.
+func (e *Exporter) RemoveScheduledScraper(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remove(name)
+}
+
+// ReplaceScheduledScrapers removes the named scrapers and schedules the given
+// ones in a single step, so that scrapes and collections never see a partial
+// change. Nothing is changed when one of the scrapers to add has the name of a
+// scraper that stays scheduled, or of another one to add.
+func (e *Exporter) ReplaceScheduledScrapers(remove []string, add []*ScheduledScraper) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	removed := make(map[string]bool, len(remove))
+	for _, name := range remove {
+		removed[name] = true
+	}
+	added := make(map[string]bool, len(add))
+	for _, s := range add {
+		if _, ok := e.entries[s.name]; (ok && !removed[s.name]) || added[s.name] {
+			return fmt.Errorf("scraper %s is already scheduled", s.name)
+		}
+		added[s.name] = true
 
-		if err != nil {
+		if err := e.parseScraperFlags(s); err != nil {
+			return fmt.Errorf("scraper %s: %w", s.name, err)
+		}
+	}
+
+	for _, name := range remove {
+		e.remove(name)
+	}
+
+	for _, s := range add {
+		if err := e.add(s); err != nil {
 			return err
 		}
+		e.scheduledscrapers = append(e.scheduledscrapers, s)
+
+		if _, ok := e.entries[s.name]; ok && e.started {
+			e.scrapeInBackground(s, 0)
+		}
+	}
+
+	return nil
+}
+
+// parseScraperFlags applies the command line and environment of the exporter
+// to the flags of a scraper added after NewExporter, as NewExporter does for
+// the scrapers it is given. The flags of the exporter are declared again on a
+// separate application, only so that parsing accepts them.
+func (e *Exporter) parseScraperFlags(s *ScheduledScraper) error {
+	app := kingpin.New(e.application.Name, "")
+	if e.application.GetFlag("web.listen-port").Model().Envar != "" {
+		app.DefaultEnvars()
+	}
+
+	for _, f := range e.application.Model().Flags {
+		if app.GetFlag(f.Name) != nil || strings.HasPrefix(f.Name, s.name+".") {
+			continue
+		}
+
+		flag := app.Flag(f.Name, f.Help)
+		if f.Envar != "" {
+			flag.Envar(f.Envar)
+		} else {
+			flag.NoEnvar()
+		}
+		if f.IsBoolFlag() {
+			flag.Bool()
+		} else {
+			flag.Strings()
+		}
+	}
+
+	s.Flags(app)
+
+	_, err := app.Parse(e.args)
+	return err
+}
+
+// remove unschedules the named scraper. It must be called with e.mu held.
+func (e *Exporter) remove(name string) {
+	if id, ok := e.entries[name]; ok {
+		e.scheduler.Remove(id)
+		delete(e.entries, name)
+	}
+	delete(e.offsets, name)
+	delete(e.metrics, name)
+	delete(e.status, name)
+	e.updateReady()
+
+	for i, s := range e.scheduledscrapers {
+		if s.name == name {
+			e.scheduledscrapers = append(e.scheduledscrapers[:i], e.scheduledscrapers[i+1:]...)
+			break
+		}
+	}
+
+	e.scrapeFrequency.DeleteLabelValues(e.application.Name, name)
+	e.scrapeSuccess.DeleteLabelValues(e.application.Name, name)
+	e.scrapeLastSuccess.DeleteLabelValues(e.application.Name, name)
+	e.scrapeTotal.DeleteLabelValues(e.application.Name, name)
+	e.scrapeTimeouts.DeleteLabelValues(e.application.Name, name)
+	e.scrapeFails.DeleteLabelValues(e.application.Name, name)
+	e.scrapeDuration.DeleteLabelValues(e.application.Name, name)
+
+	log.Infof("unscheduled %s", name)
+}
 
-		log.Info(fmt.Sprintf("scheduled %s with ID: %d every %s", ss.name, id, s.schedule.frequency))
+func (e *Exporter) add(s *ScheduledScraper) error {
+	if !s.schedule.isEnabled {
+		log.Warnf("Scraper %s is not enabled", s.name)
+		return nil
 	}
 
+	bucket := NewMetrics()
+	e.scrapeFrequency.WithLabelValues(e.application.Name, s.name).Set(s.schedule.frequency.Seconds())
+	e.scrapeFails.WithLabelValues(e.application.Name, s.name)
+	e.scrapeTotal.WithLabelValues(e.application.Name, s.name)
+	e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name)
+	offset := s.schedule.offset()
+	schedule := newOffsetSchedule(time.Now(), s.schedule.frequency, offset)
+	id := e.scheduler.Schedule(schedule, cron.FuncJob(func() {
+		e.scrape(s, bucket)
+	}))
+
+	e.entries[s.name] = id
+	e.offsets[s.name] = offset
+	e.metrics[s.name] = bucket
+	e.updateReady()
+
+	log.Info(fmt.Sprintf("scheduled %s with ID: %d every %s, offset by %s", s.name, id, schedule.every, schedule.offset))
+
 	return nil
 }
 
+// scrapeInBackground runs the first scrape of s after delay, without waiting
+// for the schedule. It must be called with e.mu held.
+func (e *Exporter) scrapeInBackground(s *ScheduledScraper, delay time.Duration) {
+	bucket := e.metrics[s.name]
+
+	e.initialScrapes.Add(1)
+	go func() {
+		defer e.initialScrapes.Done()
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-e.startCtx.Done():
+				return
+			}
+		}
+		e.scrape(s, bucket)
+	}()
+}
+
+// scrape runs s once, once one of the scrape slots is free, and stores the
+// metrics it returns in bucket. Waiting for a slot counts towards the scrape
+// timeout.
+func (e *Exporter) scrape(s *ScheduledScraper, bucket *Metrics) {
+	ctx, cancel := context.WithTimeout(e.scrapeCtx, s.schedule.timeout)
+	defer cancel()
+
+	if e.slots != nil {
+		select {
+		case e.slots <- struct{}{}:
+			defer func() { <-e.slots }()
+		case <-ctx.Done():
+			if e.scrapeCtx.Err() != nil {
+				return
+			}
+			log.Warnf("%s: no scrape slot free within %s", s.name, s.schedule.timeout)
+			e.scrapeTotal.WithLabelValues(e.application.Name, s.name).Inc()
+			e.scrapeFails.WithLabelValues(e.application.Name, s.name).Inc()
+			e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name).Inc()
+			e.recordScrape(s.name, ctx.Err())
+			return
+		}
+	}
+
+	e.scrapesInFlight.Inc()
+	defer e.scrapesInFlight.Dec()
+
+	log.Tracef("Scraping %s", s.name)
+
+	begin := time.Now()
+	metrics, err := AdaptScraper(s.scraper).ScrapeContext(ctx, e.connector)
+	e.scrapeDuration.WithLabelValues(e.application.Name, s.name).Observe(time.Since(begin).Seconds())
+	e.scrapeTotal.WithLabelValues(e.application.Name, s.name).Inc()
+	if err != nil {
+		log.Warnf("%s: scrape failed: %s", s.name, err)
+		e.scrapeFails.WithLabelValues(e.application.Name, s.name).Inc()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name).Inc()
+		}
+		// Metrics returned with an error replace the previous ones, which
+		// are kept otherwise.
+		if metrics != nil {
+			bucket.Put(e.convertMetrics(s, metrics))
+		}
+		e.recordScrape(s.name, err)
+		return
+	}
+	log.Tracef("%s: Received Metrics: %+v", s.name, metrics)
+	bucket.Put(e.convertMetrics(s, metrics))
+	select {
+	case <-ctx.Done():
+		e.scrapeTimeouts.WithLabelValues(e.application.Name, s.name).Inc()
+		log.Warnf("%s: scrape timed out: %s", s.name, ctx.Err())
+		e.recordScrape(s.name, ctx.Err())
+	default:
+		e.scrapeLastSuccess.WithLabelValues(e.application.Name, s.name).SetToCurrentTime()
+		e.recordScrape(s.name, nil)
+	}
+}
+
 func (e *Exporter) convertMetrics(s *ScheduledScraper, metrics []Metric) []prometheus.Metric {
 	pm := make([]prometheus.Metric, 0, len(metrics))
 
@@ -337,12 +942,41 @@ func (e *Exporter) convertMetrics(s *ScheduledScraper, metrics []Metric) []prome
 	return pm
 }
 
+// Args returns the command line arguments NewExporter parses when given
+// options: the ones set by WithArgs, or else those of the process.
+func Args(options ...func(*Exporter)) []string {
+	e := &Exporter{args: os.Args[1:], configured: make(map[string]string)}
+	e.apply(options)
+	return e.args
+}
+
 func WithArgs(args []string) func(*Exporter) {
 	return func(e *Exporter) {
 		e.args = args
 	}
 }
 
+func WithListenAddress(a string) func(*Exporter) {
+	return func(e *Exporter) {
+		e.listenAddress = a
+		e.configured["web.listen-address"] = a
+	}
+}
+
+func WithListenPort(p int) func(*Exporter) {
+	return func(e *Exporter) {
+		e.listenPort = p
+		e.configured["web.listen-port"] = strconv.Itoa(p)
+	}
+}
+
+func WithLogLevel(l string) func(*Exporter) {
+	return func(e *Exporter) {
+		e.logLevel = l
+		e.configured["log.level"] = l
+	}
+}
+
 func WithLabels(l Labeler) func(*Exporter) {
 	return func(e *Exporter) {
 		e.labels = l
@@ -355,6 +989,31 @@ func WithScheduler(s Scheduler) func(*Exporter) {
 	}
 }
 
+func WithReloader(r Reloader) func(*Exporter) {
+	return func(e *Exporter) {
+		e.reloader = r
+	}
+}
+
+func WithAdvertiser(a Advertiser) func(*Exporter) {
+	return func(e *Exporter) {
+		e.advertiser = a
+	}
+}
+
+func WithShutdownGracePeriod(d time.Duration) func(*Exporter) {
+	return func(e *Exporter) {
+		e.gracePeriod = d
+	}
+}
+
+func WithMaxConcurrency(n int) func(*Exporter) {
+	return func(e *Exporter) {
+		e.maxConcurrency = n
+		e.configured["scrape.max-concurrency"] = strconv.Itoa(n)
+	}
+}
+
 func WithScheduledScrapers(ss ...*ScheduledScraper) func(*Exporter) {
 	return func(e *Exporter) {
 		e.scheduledscrapers = ss
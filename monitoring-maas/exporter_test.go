@@ -1,8 +1,19 @@
 package maas
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 	"github.com/alecthomas/kingpin/v2"
 )
@@ -34,6 +45,70 @@ func (s *ExporterTestSuite) TestSuccessConnect() {
 	s.NoError(err)
 }
 
+func (s *ExporterTestSuite) TestConfiguredListenPort() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithListenAddress("0.0.0.0"),
+		WithListenPort(9091),
+		WithLogLevel("info"),
+		WithArgs([]string{}))
+	s.NoError(err)
+	s.Equal("0.0.0.0", e.listenAddress)
+	s.Equal(9091, e.listenPort)
+	s.Equal("info", e.logLevel)
+}
+
+func (s *ExporterTestSuite) TestFlagConflictsWithConfiguredListenPort() {
+	_, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithListenPort(9091),
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}))
+	s.EqualError(err, "--web.listen-port=9100 conflicts with configured value 9091")
+}
+
+func (s *ExporterTestSuite) TestFlagMatchingConfiguredListenPort() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithListenPort(9091),
+		WithArgs([]string{
+			"--web.listen-port=9091",
+		}))
+	s.NoError(err)
+	s.Equal(9091, e.listenPort)
+}
+
+func (s *ExporterTestSuite) TestFlagMatchingConfiguredLogLevelIgnoresCase() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithListenPort(9091),
+		WithLogLevel("info"),
+		WithArgs([]string{
+			"--log.level=INFO",
+		}))
+	s.NoError(err)
+	s.Equal("INFO", e.logLevel)
+}
+
+func (s *ExporterTestSuite) TestFlagSetsUnconfiguredLogLevel() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithListenPort(9091),
+		WithArgs([]string{
+			"--log.level=warn",
+		}))
+	s.NoError(err)
+	s.Equal("warn", e.logLevel)
+}
+
+func (s *ExporterTestSuite) TestListenPortRequired() {
+	_, err := NewExporter(s.Application, &SuccessConnector{},
+		WithLabels(&MockLabels{}),
+		WithArgs([]string{}))
+	s.EqualError(err, "required flag(s) '--web.listen-port' not provided")
+}
+
 func (s *ExporterTestSuite) TestSchedulesScrapes() {
 	e, err := NewExporter(s.Application, &SuccessConnector{},
 		WithArgs([]string{
@@ -51,6 +126,571 @@ func (s *ExporterTestSuite) TestSchedulesScrapes() {
 	e.Start()
 }
 
+func (s *ExporterTestSuite) TestAddRemoveScheduledScrapers() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("mock", MockScraper{}),
+		),
+	)
+	s.NoError(err)
+
+	s.NoError(e.AddScheduledScraper(NewScheduledScraper("other", MockScraper{})))
+	s.Len(e.scheduler.Entries(), 2)
+	s.Contains(e.metrics, "other")
+
+	s.EqualError(e.AddScheduledScraper(NewScheduledScraper("other", MockScraper{})), "scraper other is already scheduled")
+
+	e.RemoveScheduledScraper("mock")
+	s.Len(e.scheduler.Entries(), 1)
+	s.NotContains(e.metrics, "mock")
+	s.Len(e.scheduledscrapers, 1)
+}
+
+func (s *ExporterTestSuite) TestReplaceScheduledScrapers() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("mock", MockScraper{}),
+			NewScheduledScraper("other", MockScraper{}),
+		),
+	)
+	s.NoError(err)
+
+	s.NoError(e.ReplaceScheduledScrapers([]string{"mock"}, []*ScheduledScraper{
+		NewScheduledScraper("mock", MockScraper{}),
+		NewScheduledScraper("new", MockScraper{}),
+	}))
+	s.Len(e.scheduler.Entries(), 3)
+	s.Contains(e.metrics, "new")
+
+	s.EqualError(e.ReplaceScheduledScrapers([]string{"mock"}, []*ScheduledScraper{
+		NewScheduledScraper("added", MockScraper{}),
+		NewScheduledScraper("other", MockScraper{}),
+	}), "scraper other is already scheduled")
+	s.Len(e.scheduler.Entries(), 3)
+	s.Contains(e.metrics, "mock")
+	s.NotContains(e.metrics, "added")
+}
+
+func (s *ExporterTestSuite) TestReplacedScraperKeepsFlags() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--mock.jitter=5s",
+			"--no-mock.enabled",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(NewScheduledScraper("mock", MockScraper{})),
+	)
+	s.Require().NoError(err)
+
+	replaced := NewScheduledScraper("mock", MockScraper{})
+	s.NoError(e.ReplaceScheduledScrapers([]string{"mock"}, []*ScheduledScraper{replaced}))
+	s.Equal(5*time.Second, replaced.schedule.jitter)
+	s.False(replaced.schedule.isEnabled)
+}
+
+// deadlineScraper waits until its context is done.
+type deadlineScraper struct {
+	MockScraper
+	mu       sync.Mutex
+	deadline time.Time
+}
+
+func (s *deadlineScraper) ScrapeContext(ctx context.Context, c Connector) ([]Metric, error) {
+	s.mu.Lock()
+	s.deadline, _ = ctx.Deadline()
+	s.mu.Unlock()
+
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *ExporterTestSuite) TestContextScraperTimeout() {
+	scraper := &deadlineScraper{}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("slow", scraper, WithSchedule(NewSchedule(WithTimeout(10*time.Millisecond)))),
+		),
+	)
+	s.NoError(err)
+
+	begin := time.Now()
+	e.Start()
+	e.initialScrapes.Wait()
+
+	s.WithinDuration(begin.Add(10*time.Millisecond), scraper.deadline, 50*time.Millisecond)
+	total := testutil.ToFloat64(e.scrapeTotal.WithLabelValues("app", "slow"))
+	s.NotZero(total)
+	s.Equal(total, testutil.ToFloat64(e.scrapeFails.WithLabelValues("app", "slow")))
+	s.Equal(total, testutil.ToFloat64(e.scrapeTimeouts.WithLabelValues("app", "slow")))
+}
+
+func (s *ExporterTestSuite) TestCancelScrapes() {
+	scraper := &deadlineScraper{}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("slow", scraper, WithSchedule(NewSchedule(WithTimeout(time.Hour)))),
+		),
+	)
+	s.NoError(err)
+
+	done := make(chan struct{})
+	go func() {
+		e.Start()
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	e.CancelScrapes()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("scrapes were not cancelled")
+	}
+	e.initialScrapes.Wait()
+	s.Zero(testutil.ToFloat64(e.scrapeTimeouts.WithLabelValues("app", "slow")))
+}
+
+// resultScraper returns its results in turn.
+type resultScraper struct {
+	results []scrapeResult
+}
+
+type scrapeResult struct {
+	metrics []Metric
+	err     error
+}
+
+func (s *resultScraper) Scrape(c Connector) ([]Metric, error) {
+	r := s.results[0]
+	s.results = s.results[1:]
+	return r.metrics, r.err
+}
+
+func (s *ExporterTestSuite) TestFailedScrapeReplacesMetrics() {
+	up := func(v float64) []Metric {
+		return []Metric{NewMetric("up", prometheus.GaugeValue, v, nil)}
+	}
+	down := errors.New("down")
+	scraper := &resultScraper{results: []scrapeResult{
+		{metrics: up(1)},
+		{err: down},
+		{metrics: up(0), err: down},
+		{metrics: []Metric{}, err: down},
+	}}
+
+	ss := NewScheduledScraper("flaky", scraper, WithDescription(s.Application, "up", "Whether the feed is up", nil))
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(ss),
+	)
+	s.Require().NoError(err)
+
+	exported := func(value string) error {
+		expected := "# HELP app_flaky_up Whether the feed is up\n# TYPE app_flaky_up gauge\napp_flaky_up " + value + "\n"
+		return testutil.CollectAndCompare(e, strings.NewReader(expected), "app_flaky_up")
+	}
+
+	bucket := e.metrics["flaky"]
+	e.scrape(ss, bucket)
+	s.NoError(exported("1"))
+
+	// Without metrics, a failed scrape keeps the previous ones.
+	e.scrape(ss, bucket)
+	s.NoError(exported("1"))
+
+	e.scrape(ss, bucket)
+	s.NoError(exported("0"))
+
+	e.scrape(ss, bucket)
+	s.Zero(testutil.CollectAndCount(e, "app_flaky_up"))
+	s.Equal(3.0, testutil.ToFloat64(e.scrapeFails.WithLabelValues("app", "flaky")))
+}
+
+// waitingScraper returns no metrics once release is closed.
+type waitingScraper struct {
+	MockScraper
+	release chan struct{}
+}
+
+func (s *waitingScraper) ScrapeContext(ctx context.Context, c Connector) ([]Metric, error) {
+	select {
+	case <-s.release:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *ExporterTestSuite) TestStartDoesNotWaitForScrapes() {
+	scraper := &waitingScraper{release: make(chan struct{})}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("slow", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithTimeout(time.Hour)))),
+			NewScheduledScraper("disabled", MockScraper{}, WithSchedule(NewSchedule(Disabled()))),
+		),
+	)
+	s.Require().NoError(err)
+	defer e.CancelScrapes()
+
+	s.False(e.Ready())
+	e.Start()
+	s.False(e.Ready())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(e.WaitReady(ctx), context.DeadlineExceeded)
+
+	close(scraper.release)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.NoError(e.WaitReady(ctx))
+	s.True(e.Ready())
+}
+
+func (s *ExporterTestSuite) TestMaxConcurrency() {
+	scraper := &waitingScraper{release: make(chan struct{})}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--scrape.max-concurrency=2",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("a", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithTimeout(time.Hour)))),
+			NewScheduledScraper("b", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithTimeout(time.Hour)))),
+			NewScheduledScraper("c", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithTimeout(time.Hour)))),
+		),
+	)
+	s.Require().NoError(err)
+	defer e.CancelScrapes()
+
+	e.Start()
+
+	s.Eventually(func() bool {
+		return testutil.ToFloat64(e.scrapesInFlight) == 2
+	}, time.Second, time.Millisecond)
+	s.Never(func() bool {
+		return testutil.ToFloat64(e.scrapesInFlight) > 2
+	}, 20*time.Millisecond, time.Millisecond)
+
+	close(scraper.release)
+	e.initialScrapes.Wait()
+
+	s.Zero(testutil.ToFloat64(e.scrapesInFlight))
+	s.True(e.Ready())
+}
+
+func (s *ExporterTestSuite) TestSlotWaitBoundedByTimeout() {
+	ss := NewScheduledScraper("queued", MockScraper{}, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithTimeout(20*time.Millisecond))))
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--scrape.max-concurrency=1",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(ss),
+	)
+	s.Require().NoError(err)
+
+	// Occupy the only slot.
+	e.slots <- struct{}{}
+
+	done := make(chan struct{})
+	go func() {
+		e.scrape(ss, e.metrics["queued"])
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.FailNow("scrape kept waiting for a slot past its timeout")
+	}
+	s.Equal(1.0, testutil.ToFloat64(e.scrapeTimeouts.WithLabelValues("app", "queued")))
+	s.Equal(1.0, testutil.ToFloat64(e.scrapeFails.WithLabelValues("app", "queued")))
+	s.True(e.Ready())
+}
+
+func (s *ExporterTestSuite) TestStartupJitterDelaysFirstScrape() {
+	scraper := &countingScraper{}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("delayed", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithJitter(time.Hour)))),
+		),
+	)
+	s.Require().NoError(err)
+	e.offsets["delayed"] = time.Hour
+
+	e.Start()
+	defer e.scheduler.Stop()
+	s.Never(func() bool {
+		return scraper.count() > 0
+	}, 20*time.Millisecond, time.Millisecond)
+
+	e.cancelStart()
+	e.initialScrapes.Wait()
+	s.Zero(scraper.count())
+}
+
+func (s *ExporterTestSuite) TestNoStartupJitter() {
+	scraper := &countingScraper{}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--no-scrape.startup-jitter",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("immediate", scraper, WithSchedule(NewSchedule(WithFrequency(time.Hour), WithJitter(time.Hour)))),
+		),
+	)
+	s.Require().NoError(err)
+	e.offsets["immediate"] = time.Hour
+
+	e.Start()
+	defer e.scheduler.Stop()
+	e.initialScrapes.Wait()
+	s.Equal(1, scraper.count())
+}
+
+// countingScraper counts the scrapes it served.
+type countingScraper struct {
+	MockScraper
+	mu      sync.Mutex
+	scrapes int
+}
+
+func (s *countingScraper) Scrape(c Connector) ([]Metric, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scrapes++
+	return nil, nil
+}
+
+func (s *countingScraper) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scrapes
+}
+
+type mockReloader struct {
+	err error
+}
+
+func (r *mockReloader) Reload() error {
+	return r.err
+}
+
+func (s *ExporterTestSuite) TestReload() {
+	r := &mockReloader{}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithLabels(&MockLabels{}),
+		WithReloader(r),
+	)
+	s.NoError(err)
+
+	s.NoError(e.Reload())
+	s.Equal(1.0, testutil.ToFloat64(e.reloadSuccess))
+
+	r.err = errors.New("bad config")
+	s.EqualError(e.Reload(), "bad config")
+	s.Equal(0.0, testutil.ToFloat64(e.reloadSuccess))
+}
+
+func (s *ExporterTestSuite) TestNoReloadAfterShutdown() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(NewMockScheduler()),
+		WithLabels(&MockLabels{}),
+		WithReloader(&mockReloader{}),
+	)
+	s.Require().NoError(err)
+
+	s.NoError(e.Shutdown(context.Background()))
+	s.EqualError(e.Reload(), "exporter is shutting down")
+}
+
+// watchingReloader records whether its Watch has returned.
+type watchingReloader struct {
+	mockReloader
+	stopped chan struct{}
+}
+
+func (r *watchingReloader) Watch(ctx context.Context) {
+	<-ctx.Done()
+	close(r.stopped)
+}
+
+type mockAdvertiser struct {
+	advertised   bool
+	deregistered bool
+}
+
+func (a *mockAdvertiser) Flags(*kingpin.Application) {}
+
+func (a *mockAdvertiser) Advertise(string, int, Labeler) error {
+	a.advertised = true
+	return nil
+}
+
+func (a *mockAdvertiser) Deregister() error {
+	a.deregistered = true
+	return nil
+}
+
+func (s *ExporterTestSuite) TestRunShutsDown() {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	port := l.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(l.Close())
+
+	advertiser := &mockAdvertiser{}
+	reloader := &watchingReloader{stopped: make(chan struct{})}
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			fmt.Sprintf("--web.listen-port=%d", port),
+		}),
+		WithLabels(&MockLabels{}),
+		WithAdvertiser(advertiser),
+		WithReloader(reloader),
+	)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- e.Run(ctx)
+	}()
+
+	s.Eventually(func() bool {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/health", port))
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+	s.True(advertiser.advertised)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.Fail("exporter did not shut down")
+	}
+	s.True(advertiser.deregistered)
+
+	select {
+	case <-reloader.stopped:
+	default:
+		s.Fail("watch did not stop")
+	}
+
+	_, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/health", port))
+	s.Error(err)
+}
+
+func (s *ExporterTestSuite) TestAdvertiserBackend() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--advertise.backend=http",
+		}),
+		WithLabels(&MockLabels{}),
+	)
+	s.Require().NoError(err)
+	s.IsType(&HTTPSD{}, e.advertiser)
+
+	s.NoError(e.advertise())
+	rec := httptest.NewRecorder()
+	e.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/-/sd", nil))
+	s.JSONEq(`[{"targets":["127.0.0.1:9100"]}]`, rec.Body.String())
+}
+
+func (s *ExporterTestSuite) TestNoAdvertiserWhenDisabled() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+			"--no-advertise",
+		}),
+		WithLabels(&MockLabels{}),
+	)
+	s.Require().NoError(err)
+	s.Nil(e.advertiser)
+	s.NoError(e.advertise())
+	s.NoError(e.deregister())
+}
+
+// stuckScheduler never reports its jobs as finished.
+type stuckScheduler struct {
+	*MockScheduler
+}
+
+func (s *stuckScheduler) Stop() context.Context {
+	return context.Background()
+}
+
+func (s *ExporterTestSuite) TestShutdownCancelsScrapesAfterGracePeriod() {
+	e, err := NewExporter(s.Application, &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithScheduler(&stuckScheduler{NewMockScheduler()}),
+		WithLabels(&MockLabels{}),
+	)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = e.Shutdown(ctx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.ErrorIs(e.scrapeCtx.Err(), context.Canceled)
+}
+
 func TestExporterTestSuite(t *testing.T) {
 	suite.Run(t, new(ExporterTestSuite))
 }
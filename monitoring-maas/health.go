@@ -0,0 +1,120 @@
+package maas
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// scraperStatus is the outcome of the scrapes of a scraper so far.
+type scraperStatus struct {
+	lastSuccess         time.Time
+	lastError           string
+	consecutiveFailures int
+}
+
+// ScraperHealth is the state of a scraper reported by /-/healthy. LastSuccess
+// is omitted until a scrape succeeds and LastError is cleared by the next
+// successful scrape.
+type ScraperHealth struct {
+	Name                string     `json:"name"`
+	Attempted           bool       `json:"attempted"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+// Health is the body of /-/healthy.
+type Health struct {
+	Ready    bool            `json:"ready"`
+	Scrapers []ScraperHealth `json:"scrapers"`
+}
+
+// recordScrape notes the outcome of a scrape of the named scraper, err being
+// nil for a successful one.
+func (e *Exporter) recordScrape(name string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.entries[name]; !ok {
+		return
+	}
+
+	st, ok := e.status[name]
+	if !ok {
+		st = &scraperStatus{}
+		e.status[name] = st
+	}
+
+	if err != nil {
+		st.lastError = err.Error()
+		st.consecutiveFailures++
+	} else {
+		st.lastSuccess = time.Now()
+		st.lastError = ""
+		st.consecutiveFailures = 0
+	}
+
+	if !ok {
+		e.updateReady()
+	}
+}
+
+// Health reports the state of every enabled scraper, sorted by name.
+func (e *Exporter) Health() Health {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	h := Health{Scrapers: make([]ScraperHealth, 0, len(e.entries))}
+
+	select {
+	case <-e.ready:
+		h.Ready = true
+	default:
+	}
+
+	for name := range e.entries {
+		sh := ScraperHealth{Name: name}
+		if st, ok := e.status[name]; ok {
+			sh.Attempted = true
+			sh.LastError = st.lastError
+			sh.ConsecutiveFailures = st.consecutiveFailures
+			if !st.lastSuccess.IsZero() {
+				lastSuccess := st.lastSuccess
+				sh.LastSuccess = &lastSuccess
+			}
+		}
+		h.Scrapers = append(h.Scrapers, sh)
+	}
+
+	sort.Slice(h.Scrapers, func(i, j int) bool {
+		return h.Scrapers[i].Name < h.Scrapers[j].Name
+	})
+
+	return h
+}
+
+// handleReady answers 200 once every enabled scraper has completed a scrape,
+// and 503 before.
+func (e *Exporter) handleReady(w http.ResponseWriter, r *http.Request) {
+	if !e.Ready() {
+		http.Error(w, "waiting for the first scrape of every scraper", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Write([]byte("OK"))
+}
+
+// handleHealthy answers with the Health of the exporter. The status is always
+// 200: failing feeds are reported in the body but are no reason to restart
+// the exporter.
+func (e *Exporter) handleHealthy(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(e.Health()); err != nil {
+		log.Warnf("unable to write health response: %s", err)
+	}
+}
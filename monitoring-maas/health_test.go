@@ -0,0 +1,98 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+type failingScraper struct{}
+
+func (s failingScraper) Scrape(c Connector) ([]Metric, error) {
+	return nil, errors.New("feed unavailable")
+}
+
+type HealthTestSuite struct {
+	suite.Suite
+	exporter *Exporter
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	var err error
+	s.exporter, err = NewExporter(kingpin.New("app", "test app"), &SuccessConnector{},
+		WithArgs([]string{
+			"--web.listen-port=9100",
+		}),
+		WithLabels(&MockLabels{}),
+		WithScheduledScrapers(
+			NewScheduledScraper("up", MockScraper{}, WithSchedule(NewSchedule(WithFrequency(time.Hour)))),
+			NewScheduledScraper("down", failingScraper{}, WithSchedule(NewSchedule(WithFrequency(time.Hour)))),
+			NewScheduledScraper("off", MockScraper{}, WithSchedule(NewSchedule(Disabled()))),
+		),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *HealthTestSuite) get(path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.exporter.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func (s *HealthTestSuite) TestReady() {
+	s.Equal(http.StatusServiceUnavailable, s.get("/-/ready").Code)
+
+	s.exporter.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	s.Require().NoError(s.exporter.WaitReady(ctx))
+
+	s.Equal(http.StatusOK, s.get("/-/ready").Code)
+}
+
+func (s *HealthTestSuite) TestHealthy() {
+	var h Health
+	rec := s.get("/-/healthy")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &h))
+	s.False(h.Ready)
+	s.Equal([]ScraperHealth{{Name: "down"}, {Name: "up"}}, h.Scrapers)
+
+	s.exporter.Start()
+	s.exporter.initialScrapes.Wait()
+	s.exporter.recordScrape("down", errors.New("feed still unavailable"))
+
+	rec = s.get("/-/healthy")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &h))
+	s.True(h.Ready)
+	s.Require().Len(h.Scrapers, 2)
+
+	down, up := h.Scrapers[0], h.Scrapers[1]
+	s.True(down.Attempted)
+	s.Nil(down.LastSuccess)
+	s.Equal("feed still unavailable", down.LastError)
+	s.Equal(2, down.ConsecutiveFailures)
+
+	s.True(up.Attempted)
+	s.Require().NotNil(up.LastSuccess)
+	s.WithinDuration(time.Now(), *up.LastSuccess, time.Second)
+	s.Empty(up.LastError)
+	s.Zero(up.ConsecutiveFailures)
+
+	s.exporter.recordScrape("down", nil)
+	s.Zero(s.exporter.Health().Scrapers[0].ConsecutiveFailures)
+	s.Empty(s.exporter.Health().Scrapers[0].LastError)
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
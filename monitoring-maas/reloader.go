@@ -0,0 +1,16 @@
+package maas
+
+import "context"
+
+// Reloader applies a new configuration to a running exporter. Reloads are
+// triggered by SIGHUP, a POST to /-/reload or by calling Exporter.Reload.
+type Reloader interface {
+	Reload() error
+}
+
+// Watcher is implemented by Reloaders that detect configuration changes
+// themselves, such as by polling a file. Run calls Watch in the background and
+// waits for it to return once its context is done.
+type Watcher interface {
+	Watch(ctx context.Context)
+}
@@ -1,6 +1,7 @@
 package maas
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -9,6 +10,7 @@ const defaultFrequency = 10 * time.Second
 type Schedule struct {
 	frequency time.Duration
 	timeout   time.Duration
+	jitter    time.Duration
 	isEnabled bool
 }
 
@@ -36,12 +38,53 @@ func WithTimeout(t time.Duration) func(*Schedule) {
 	}
 }
 
+// WithJitter spreads scheduled scrapes: each scraper is given a random offset
+// below j, shifting all of its scheduled runs by the same amount.
+func WithJitter(j time.Duration) func(*Schedule) {
+	return func(s *Schedule) {
+		s.jitter = j
+	}
+}
+
 func Disabled() func(*Schedule) {
 	return func(s *Schedule) {
 		s.isEnabled = false
 	}
 }
 
+// offset returns a random delay below the jitter of the schedule.
+func (s *Schedule) offset() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.jitter)))
+}
+
+// offsetSchedule is a cron.Schedule firing every interval, shifted by offset
+// from the time it was created.
+type offsetSchedule struct {
+	first  time.Time
+	every  time.Duration
+	offset time.Duration
+}
+
+// newOffsetSchedule returns a schedule whose first run is one interval plus
+// offset after start. Like the cron @every descriptor it runs at most once a
+// second.
+func newOffsetSchedule(start time.Time, every, offset time.Duration) offsetSchedule {
+	if every < time.Second {
+		every = time.Second
+	}
+	return offsetSchedule{first: start.Add(every + offset), every: every, offset: offset}
+}
+
+func (s offsetSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.first) {
+		return s.first
+	}
+	return s.first.Add((t.Sub(s.first)/s.every + 1) * s.every)
+}
+
 func (s *Schedule) apply(options []func(*Schedule)) {
 	for _, option := range options {
 		option(s)
@@ -17,18 +17,49 @@ func (s *ScheduleTestSuite) TestDefaultSchedule() {
 	sc := NewSchedule()
 	s.Equal(sc.frequency, frequency)
 	s.Equal(sc.timeout, time.Second)
+	s.Zero(sc.jitter)
 	s.True(sc.isEnabled)
+	s.Zero(sc.offset())
 }
 
 func (s *ScheduleTestSuite) TestCustomSchedule() {
 	sc := NewSchedule(
 		WithFrequency(time.Hour),
 		WithTimeout(time.Minute),
+		WithJitter(time.Second),
 		Disabled(),
 	)
 	s.Equal(sc.frequency, time.Hour)
 	s.Equal(sc.timeout, time.Minute)
+	s.Equal(sc.jitter, time.Second)
 	s.False(sc.isEnabled)
+
+	for i := 0; i < 100; i++ {
+		offset := sc.offset()
+		s.GreaterOrEqual(offset, time.Duration(0))
+		s.Less(offset, time.Second)
+	}
+}
+
+func (s *ScheduleTestSuite) TestOffsetSchedule() {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sc := newOffsetSchedule(start, time.Minute, 10*time.Second)
+
+	tests := []struct {
+		now  time.Time
+		next time.Time
+	}{
+		{now: start, next: start.Add(70 * time.Second)},
+		{now: start.Add(70 * time.Second), next: start.Add(130 * time.Second)},
+		{now: start.Add(71 * time.Second), next: start.Add(130 * time.Second)},
+		{now: start.Add(time.Hour), next: start.Add(time.Hour + 10*time.Second)},
+	}
+
+	for _, tc := range tests {
+		s.Equal(tc.next, sc.Next(tc.now), tc.now)
+	}
+
+	s.Equal(time.Second, newOffsetSchedule(start, 0, 0).every)
 }
 
 func TestScheduleTestSuite(t *testing.T) {
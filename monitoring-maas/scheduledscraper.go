@@ -15,6 +15,9 @@ type Scheduler interface {
 	Start()
 	Stop() context.Context
 	AddFunc(spec string, cmd func()) (cron.EntryID, error)
+	Schedule(schedule cron.Schedule, cmd cron.Job) cron.EntryID
+	Entry(id cron.EntryID) cron.Entry
+	Remove(id cron.EntryID)
 }
 
 type ScheduledScraper struct {
@@ -46,6 +49,10 @@ func (s ScheduledScraper) Flags(a *kingpin.Application) {
 		Default(s.schedule.timeout.String()).
 		DurationVar(&s.schedule.timeout)
 
+	a.Flag(fmt.Sprintf("%s.jitter", s.name), fmt.Sprintf("Upper bound of the random delay of scheduled scrapes of %s", s.name)).
+		Default(s.schedule.jitter.String()).
+		DurationVar(&s.schedule.jitter)
+
 	a.Flag(fmt.Sprintf("%s.enabled", s.name), fmt.Sprintf("Is scraper %s enabled", s.name)).
 		Default(strconv.FormatBool(s.schedule.isEnabled)).
 		BoolVar(&s.schedule.isEnabled)
@@ -1,6 +1,10 @@
 package maas
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 type Metric struct {
 	name      string
@@ -18,6 +22,54 @@ func NewMetric(n string, t prometheus.ValueType, v float64, l []string) Metric {
 	}
 }
 
+// Scraper produces the metrics of one scheduled scrape. When Scrape fails the
+// metrics exported by the previous scrape are kept, unless it returns metrics
+// along with the error: those replace them, an empty slice dropping them all.
 type Scraper interface {
 	Scrape(c Connector) ([]Metric, error)
 }
+
+// ContextScraper is implemented by scrapers that stop when ctx is done. The
+// exporter calls ScrapeContext instead of Scrape, with a context that expires
+// after the timeout of the schedule.
+type ContextScraper interface {
+	Scraper
+	ScrapeContext(ctx context.Context, c Connector) ([]Metric, error)
+}
+
+// AdaptScraper returns s when it implements ContextScraper. Other scrapers are
+// wrapped so ScrapeContext returns as soon as ctx is done; the scrape itself
+// runs to completion in the background and its metrics are dropped.
+func AdaptScraper(s Scraper) ContextScraper {
+	if cs, ok := s.(ContextScraper); ok {
+		return cs
+	}
+	return scraperAdapter{s}
+}
+
+type scraperAdapter struct {
+	Scraper
+}
+
+func (a scraperAdapter) ScrapeContext(ctx context.Context, c Connector) ([]Metric, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		metrics []Metric
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		metrics, err := a.Scrape(c)
+		done <- result{metrics, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.metrics, r.err
+	}
+}
@@ -0,0 +1,156 @@
+package maas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/alecthomas/kingpin/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// targetGroup is a target group in the format read by the Prometheus
+// file_sd_configs and http_sd_configs.
+type targetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+func newTargetGroup(address string, port int, labels Labeler) targetGroup {
+	return targetGroup{
+		Targets: []string{fmt.Sprintf("%s:%d", address, port)},
+		Labels:  labels.Labels(),
+	}
+}
+
+// FileSD advertises the exporter by writing a file for the Prometheus
+// file_sd_configs. The file is removed on Deregister.
+type FileSD struct {
+	path    string
+	written bool
+}
+
+func NewFileSD(options ...func(*FileSD)) *FileSD {
+	f := &FileSD{}
+
+	for _, option := range options {
+		option(f)
+	}
+
+	return f
+}
+
+func WithFileSDPath(path string) func(*FileSD) {
+	return func(f *FileSD) {
+		f.path = path
+	}
+}
+
+func (f *FileSD) Flags(a *kingpin.Application) {
+	a.Flag("advertise.file-sd.path",
+		"File to write the target to when advertising with the file backend").
+		Default(f.path).StringVar(&f.path)
+}
+
+// Advertise writes the target group of the exporter to the file. The file is
+// replaced atomically so Prometheus never reads a partial one.
+func (f *FileSD) Advertise(address string, port int, labels Labeler) error {
+	if f.path == "" {
+		return errors.New("no file_sd path provided")
+	}
+
+	data, err := json.MarshalIndent([]targetGroup{newTargetGroup(address, port, labels)}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return err
+	}
+	f.written = true
+
+	log.Infof("advertised exporter in %s", f.path)
+
+	return nil
+}
+
+// Deregister removes the file written by Advertise, if any.
+func (f *FileSD) Deregister() error {
+	if !f.written {
+		return nil
+	}
+
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	f.written = false
+
+	return nil
+}
+
+// HTTPSD advertises the exporter through the exporter itself: it is an
+// http.Handler answering the Prometheus http_sd_configs with the target of
+// the exporter, or with no target once deregistered.
+type HTTPSD struct {
+	mu     sync.RWMutex
+	groups []targetGroup
+}
+
+func NewHTTPSD() *HTTPSD {
+	return &HTTPSD{}
+}
+
+func (h *HTTPSD) Flags(a *kingpin.Application) {}
+
+func (h *HTTPSD) Advertise(address string, port int, labels Labeler) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.groups = []targetGroup{newTargetGroup(address, port, labels)}
+
+	return nil
+}
+
+func (h *HTTPSD) Deregister() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.groups = nil
+
+	return nil
+}
+
+func (h *HTTPSD) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	groups := h.groups
+	h.mu.RUnlock()
+
+	if groups == nil {
+		groups = []targetGroup{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(groups); err != nil {
+		log.Warnf("unable to write service discovery response: %s", err)
+	}
+}
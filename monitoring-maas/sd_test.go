@@ -0,0 +1,98 @@
+package maas
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+type SDTestSuite struct {
+	suite.Suite
+	*kingpin.Application
+	Labeler
+}
+
+func (s *SDTestSuite) SetupTest() {
+	s.Application = kingpin.New("test", "help")
+	s.Labeler = NewDefaultLabels()
+	s.Labeler.Flags(s.Application)
+}
+
+func (s *SDTestSuite) parse(args ...string) {
+	_, err := s.Application.Parse(append([]string{
+		"--labels.fqdn=maas.sabio.co.uk",
+		"--labels.location=glasgow",
+		"--labels.ip-address=192.168.0.1",
+		"--labels.country=GB",
+	}, args...))
+	s.Require().NoError(err)
+}
+
+func (s *SDTestSuite) TestFileSD() {
+	path := filepath.Join(s.T().TempDir(), "targets.json")
+
+	f := NewFileSD()
+	f.Flags(s.Application)
+	s.parse("--advertise.file-sd.path=" + path)
+
+	s.NoError(f.Advertise("127.0.0.1", 9100, s.Labeler))
+
+	data, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	var groups []targetGroup
+	s.Require().NoError(json.Unmarshal(data, &groups))
+	s.Require().Len(groups, 1)
+	s.Equal([]string{"127.0.0.1:9100"}, groups[0].Targets)
+	s.Equal("glasgow", groups[0].Labels["location"])
+	s.Equal("test", groups[0].Labels["exporter"])
+
+	s.NoError(f.Deregister())
+	s.NoFileExists(path)
+	s.NoError(f.Deregister())
+}
+
+func (s *SDTestSuite) TestFileSDWithoutPath() {
+	f := NewFileSD()
+	f.Flags(s.Application)
+	s.parse()
+
+	s.EqualError(f.Advertise("127.0.0.1", 9100, s.Labeler), "no file_sd path provided")
+}
+
+func (s *SDTestSuite) TestHTTPSD() {
+	h := NewHTTPSD()
+	h.Flags(s.Application)
+	s.parse()
+
+	get := func() []targetGroup {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/-/sd", nil))
+		s.Equal("application/json", rec.Header().Get("Content-Type"))
+
+		var groups []targetGroup
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &groups))
+		return groups
+	}
+
+	s.Empty(get())
+
+	s.NoError(h.Advertise("127.0.0.1", 9100, s.Labeler))
+	groups := get()
+	s.Require().Len(groups, 1)
+	s.Equal([]string{"127.0.0.1:9100"}, groups[0].Targets)
+	s.Equal("GB", groups[0].Labels["country"])
+
+	s.NoError(h.Deregister())
+	s.Empty(get())
+}
+
+func TestSDTestSuite(t *testing.T) {
+	suite.Run(t, new(SDTestSuite))
+}
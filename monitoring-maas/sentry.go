@@ -3,6 +3,7 @@ package maas
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/onrik/logrus/sentry"
 	"github.com/prometheus/common/version"
@@ -11,6 +12,10 @@ import (
 
 var SentryDSN string
 
+// sentryHook is the hook added by RegisterSentryHook, kept so that pending
+// events can be flushed on shutdown.
+var sentryHook *sentry.Hook
+
 func RegisterSentryHook(l Labeler) error {
 	if SentryDSN == "" {
 		return errors.New("no DSN provided")
@@ -42,8 +47,20 @@ func RegisterSentryHook(l Labeler) error {
 	}
 
 	log.AddHook(h)
+	sentryHook = h
 
 	log.Infof("registered sentry hook with DSN: %s", SentryDSN)
 
 	return nil
 }
+
+// FlushSentry waits up to timeout for events queued by the Sentry hook to be
+// sent. It does nothing when no hook was registered.
+func FlushSentry(timeout time.Duration) {
+	if sentryHook == nil {
+		return
+	}
+
+	sentryHook.SetFlushTimeout(timeout)
+	sentryHook.Flush()
+}
@@ -1,10 +1,105 @@
 package maas
 
-// ServiceFeed represents configuration for a single RSS/Atom feed service
+import "time"
+
+// ServiceFeed represents configuration for a single RSS/Atom feed service.
+// Type selects how URL is read: "feed" (the default) or "statuspage" for the
+// API of an Atlassian Statuspage page. Include and Exclude scope which
+// incidents affect the status of the service, HTTPClient how URL is requested
+// and Retry how failed requests are retried. Staleness decides what is
+// reported while the feed cannot be read. Interval and Jitter are in seconds.
 type ServiceFeed struct {
-	Name     string `yaml:"name"`
-	Provider string `yaml:"provider"`
-	Customer string `yaml:"customer"`
-	URL      string `yaml:"url"`
-	Interval int    `yaml:"interval"`
-}
\ No newline at end of file
+	Name          string            `yaml:"name"`
+	Type          string            `yaml:"type,omitempty"`
+	Provider      string            `yaml:"provider"`
+	Customer      string            `yaml:"customer"`
+	URL           string            `yaml:"url"`
+	Interval      int               `yaml:"interval"`
+	Jitter        int               `yaml:"jitter,omitempty"`
+	Rules         *FeedRules        `yaml:"rules,omitempty"`
+	Include       *IncidentFilter   `yaml:"include,omitempty"`
+	Exclude       *IncidentFilter   `yaml:"exclude,omitempty"`
+	ExportIgnored bool              `yaml:"export_ignored,omitempty"`
+	HTTPClient    *HTTPClientConfig `yaml:"http_client,omitempty"`
+	Retry         *RetryPolicy      `yaml:"retry,omitempty"`
+	Staleness     *StalenessPolicy  `yaml:"staleness,omitempty"`
+}
+
+// StalenessPolicy decides what happens to the metrics of a feed that keeps
+// failing. Policy is "unknown" (the default) to report the unknown state,
+// "keep" to go on reporting the last status or "drop" to stop reporting it.
+// Drop and unknown apply once MaxFailures scrapes in a row have failed or the
+// last successful scrape is older than MaxAge, or straight away when neither
+// is set.
+type StalenessPolicy struct {
+	Policy      string        `yaml:"policy,omitempty"`
+	MaxFailures int           `yaml:"max_failures,omitempty"`
+	MaxAge      time.Duration `yaml:"max_age,omitempty"`
+}
+
+// HTTPClientConfig configures how a feed is requested. Credentials are read
+// from files on every request, so they can be rotated without a reload.
+type HTTPClientConfig struct {
+	ProxyURL           string            `yaml:"proxy_url,omitempty"`
+	NoProxy            string            `yaml:"no_proxy,omitempty"`
+	CAFile             string            `yaml:"ca_file,omitempty"`
+	CertFile           string            `yaml:"cert_file,omitempty"`
+	KeyFile            string            `yaml:"key_file,omitempty"`
+	InsecureSkipVerify bool              `yaml:"insecure_skip_verify,omitempty"`
+	Headers            map[string]string `yaml:"headers,omitempty"`
+	UserAgent          string            `yaml:"user_agent,omitempty"`
+	BasicAuth          *BasicAuth        `yaml:"basic_auth,omitempty"`
+	BearerTokenFile    string            `yaml:"bearer_token_file,omitempty"`
+	Timeout            time.Duration     `yaml:"timeout,omitempty"`
+}
+
+// RetryPolicy configures how failed requests are retried. Zero values select
+// the defaults of the connector. Backoff doubles from BaseBackoff up to
+// MaxBackoff, and Jitter is the fraction of each wait that is randomised.
+type RetryPolicy struct {
+	MaxAttempts       int           `yaml:"max_attempts,omitempty"`
+	BaseBackoff       time.Duration `yaml:"base_backoff,omitempty"`
+	MaxBackoff        time.Duration `yaml:"max_backoff,omitempty"`
+	Jitter            float64       `yaml:"jitter,omitempty"`
+	RetryOn           []int         `yaml:"retry_on,omitempty"`
+	RespectRetryAfter *bool         `yaml:"respect_retry_after,omitempty"`
+}
+
+// BasicAuth sends Username and the password stored in PasswordFile.
+type BasicAuth struct {
+	Username     string `yaml:"username"`
+	PasswordFile string `yaml:"password_file"`
+}
+
+// IncidentFilter matches incidents affecting one of Services, in one of
+// Regions, with a title matching the Title regular expression. Empty fields
+// match every incident.
+type IncidentFilter struct {
+	Services []string `yaml:"services"`
+	Regions  []string `yaml:"regions"`
+	Title    string   `yaml:"title"`
+}
+
+// FeedRules configures the rule-based parser selected with `provider: rules`.
+type FeedRules struct {
+	States      []StateRule `yaml:"states"`
+	Service     []FieldRule `yaml:"service"`
+	Region      []FieldRule `yaml:"region"`
+	IncidentKey string      `yaml:"incident_key"`
+}
+
+// StateRule assigns State, and optionally Severity, to items matching Regex or
+// containing any of the Contains keywords in one of Fields.
+type StateRule struct {
+	State    string   `yaml:"state"`
+	Regex    string   `yaml:"regex"`
+	Contains []string `yaml:"contains"`
+	Fields   []string `yaml:"fields"`
+	Severity string   `yaml:"severity,omitempty"`
+}
+
+// FieldRule extracts a value from an item field with a regular expression.
+type FieldRule struct {
+	Field string `yaml:"field"`
+	Regex string `yaml:"regex"`
+}